@@ -45,7 +45,7 @@ resource "nexaa_container" "container" {
   image      = "nginx"
   registry   = null
 
-  resources = data.nexaa_container_resources.container_resource.id
+  resources = { cpu = 0.25, ram = 0.5 }
 
   ports = ["8000:8000", "80:80", "8008:8008"]
 
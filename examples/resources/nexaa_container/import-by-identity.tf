@@ -0,0 +1,7 @@
+import {
+  to = nexaa_container.example
+  identity = {
+    namespace = "namespace"
+    name      = "container_name"
+  }
+}
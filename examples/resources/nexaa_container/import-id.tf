@@ -0,0 +1,4 @@
+import {
+  to = nexaa_container.example
+  id = "namespace/container_name"
+}
@@ -1,8 +1,3 @@
-data "nexaa_container_resources" "container_resource" {
-  cpu    = 0.25
-  memory = 0.5
-}
-
 resource "nexaa_container" "container" {
   ## We need a namespace before we can create a container. Therefor create a dependancy on the namespace
   depends_on = [
@@ -19,7 +14,11 @@ resource "nexaa_container" "container" {
   # command    = ["nginx", "-g", "daemon off;"]
   # entrypoint = ["/docker-entrypoint.sh"]
 
-  resources = data.nexaa_container_resources.container_resource.id
+  ## The amount of cpu and ram (in GB) for the container
+  resources = {
+    cpu = 0.25
+    ram = 0.5
+  }
 
   ## Exposing ports from the container.
   ## This is required when you want to communicate from outside the container to this container
@@ -0,0 +1,7 @@
+import {
+  to = nexaa_container_job.example
+  identity = {
+    namespace = "namespace"
+    name      = "container_job_name"
+  }
+}
@@ -0,0 +1,4 @@
+import {
+  to = nexaa_container_job.example
+  id = "namespace/container_job_name"
+}
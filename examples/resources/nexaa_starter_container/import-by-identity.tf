@@ -0,0 +1,7 @@
+import {
+  to = nexaa_starter_container.example
+  identity = {
+    namespace = "namespace"
+    name      = "container_name"
+  }
+}
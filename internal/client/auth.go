@@ -0,0 +1,164 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/nexaa-cloud/nexaa-cli/config"
+)
+
+// Credentials are used to log in again when the access token expires.
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// authTransport sends every request with the current access token and logs
+// in again when the API rejects it, so long running applies survive the
+// expiry of the token obtained when the provider was configured.
+type authTransport struct {
+	next        http.RoundTripper
+	credentials Credentials
+
+	mu           sync.Mutex
+	accessToken  string
+	refreshToken string
+}
+
+func newAuthTransport(next http.RoundTripper, credentials Credentials) *authTransport {
+	return &authTransport{
+		next:         next,
+		credentials:  credentials,
+		accessToken:  config.AccessToken,
+		refreshToken: config.RefreshToken,
+	}
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Only API requests carry a token, requests to the authentication server
+	// itself are passed through untouched.
+	if req.Header.Get("Authorization") == "" {
+		return t.next.RoundTrip(req)
+	}
+
+	token := t.token()
+	resp, err := t.next.RoundTrip(withToken(req, token))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+
+	newToken, authErr := t.renew(token)
+	if authErr != nil {
+		return resp, nil
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	retry := withToken(req, newToken)
+	if req.Body != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		retry.Body = body
+	}
+	return t.next.RoundTrip(retry)
+}
+
+func (t *authTransport) token() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.accessToken
+}
+
+// renew obtains a new access token, unless another request already replaced
+// the token that was rejected.
+func (t *authTransport) renew(rejected string) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.accessToken != rejected {
+		return t.accessToken, nil
+	}
+
+	form := url.Values{}
+	if t.refreshToken != "" {
+		form.Set("grant_type", "refresh_token")
+		form.Set("refresh_token", t.refreshToken)
+		if err := t.requestToken(form); err == nil {
+			return t.accessToken, nil
+		}
+	}
+
+	if t.credentials.Username == "" {
+		return "", errors.New("no credentials available to renew the access token")
+	}
+
+	form = url.Values{}
+	form.Set("grant_type", "password")
+	form.Set("username", t.credentials.Username)
+	form.Set("password", t.credentials.Password)
+	if err := t.requestToken(form); err != nil {
+		return "", err
+	}
+	return t.accessToken, nil
+}
+
+// requestToken posts form to the token endpoint of the authentication server
+// and stores the returned tokens. The caller must hold t.mu.
+func (t *authTransport) requestToken(form url.Values) error {
+	form.Set("client_id", "cloud-tilaa")
+
+	req, err := http.NewRequest(http.MethodPost, config.KEYCLOAK_URL+"/realms/tilaa/protocol/openid-connect/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("renewing the access token failed with status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return fmt.Errorf("could not parse the token response: %w", err)
+	}
+	if body.AccessToken == "" {
+		return errors.New("the token response did not contain an access token")
+	}
+
+	t.accessToken = body.AccessToken
+	t.refreshToken = body.RefreshToken
+	return nil
+}
+
+func withToken(req *http.Request, token string) *http.Request {
+	if token == "" {
+		return req
+	}
+	clone := req.Clone(req.Context())
+	clone.Header.Set("Authorization", "Bearer "+token)
+	return clone
+}
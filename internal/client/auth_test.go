@@ -0,0 +1,125 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/nexaa-cloud/nexaa-cli/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTokenServer serves a GraphQL endpoint that only accepts validToken and a
+// token endpoint that hands out validToken for the given grant type.
+func newTokenServer(t *testing.T, validToken, acceptedGrant string, logins *int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/openid-connect/token") {
+			require.NoError(t, r.ParseForm())
+			atomic.AddInt32(logins, 1)
+			if r.PostForm.Get("grant_type") != acceptedGrant {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write([]byte(`{"access_token":"` + validToken + `","refresh_token":"next-refresh"}`))
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "query", string(body))
+		if r.Header.Get("Authorization") != "Bearer "+validToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	oldURL := config.KEYCLOAK_URL
+	config.KEYCLOAK_URL = server.URL
+	t.Cleanup(func() {
+		config.KEYCLOAK_URL = oldURL
+		server.Close()
+	})
+	return server
+}
+
+func postWithToken(t *testing.T, transport http.RoundTripper, url, token string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader("query"))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := transport.RoundTrip(req)
+	require.NoError(t, err)
+	return resp
+}
+
+func Test_AuthTransport_refreshes_expired_token(t *testing.T) {
+	var logins int32
+	server := newTokenServer(t, "fresh", "refresh_token", &logins)
+
+	transport := &authTransport{next: http.DefaultTransport, accessToken: "expired", refreshToken: "refresh"}
+	resp := postWithToken(t, transport, server.URL, "expired")
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&logins))
+	assert.Equal(t, "fresh", transport.token())
+
+	// Later requests use the new token without logging in again.
+	resp2 := postWithToken(t, transport, server.URL, "expired")
+	defer resp2.Body.Close()
+	assert.Equal(t, http.StatusOK, resp2.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&logins))
+}
+
+func Test_AuthTransport_falls_back_to_password_login(t *testing.T) {
+	var logins int32
+	server := newTokenServer(t, "fresh", "password", &logins)
+
+	transport := &authTransport{
+		next:         http.DefaultTransport,
+		credentials:  Credentials{Username: "user", Password: "secret"},
+		accessToken:  "expired",
+		refreshToken: "refresh",
+	}
+	resp := postWithToken(t, transport, server.URL, "expired")
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&logins))
+}
+
+func Test_AuthTransport_returns_unauthorized_when_renewal_fails(t *testing.T) {
+	var logins int32
+	server := newTokenServer(t, "fresh", "none", &logins)
+
+	transport := &authTransport{next: http.DefaultTransport, accessToken: "expired"}
+	resp := postWithToken(t, transport, server.URL, "expired")
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func Test_AuthTransport_passes_through_requests_without_token(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		assert.Empty(t, r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	transport := &authTransport{next: http.DefaultTransport, accessToken: "token"}
+	resp := doPost(t, transport, server.URL, "query")
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
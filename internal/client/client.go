@@ -5,6 +5,7 @@ package client
 
 import (
 	"sync"
+	"time"
 
 	"github.com/nexaa-cloud/nexaa-cli/api"
 )
@@ -55,6 +56,7 @@ type NexaaAPI interface {
 	CloudDatabaseClusterModify(input api.CloudDatabaseClusterModifyInput) (api.CloudDatabaseClusterResult, error)
 	CloudDatabaseClusterDelete(input api.CloudDatabaseClusterResourceInput) (bool, error)
 	CloudDatabaseClusterGet(input api.CloudDatabaseClusterResourceInput) (api.CloudDatabaseClusterResult, error)
+	CloudDatabaseClusterList() ([]api.CloudDatabaseClusterResult, error)
 	CloudDatabaseClusterListPlans() ([]api.CloudDatabaseClusterPlan, error)
 
 	// Cloud Database Cluster Database
@@ -66,6 +68,7 @@ type NexaaAPI interface {
 	CloudDatabaseClusterUserModify(input api.CloudDatabaseClusterUserModifyInput) (api.CloudDatabaseClusterUserResult, error)
 	CloudDatabaseClusterUserGet(input api.CloudDatabaseClusterResourceInput, name string) (api.CloudDatabaseClusterUserResult, error)
 	CloudDatabaseClusterUserList(input api.CloudDatabaseClusterResourceInput) ([]api.CloudDatabaseClusterUserResult, error)
+	CloudDatabaseClusterUserCredentials(input api.CloudDatabaseClusterResourceInput, userName string) (string, error)
 }
 
 // mutexKV is a key-value store of mutexes. It serializes concurrent operations
@@ -103,6 +106,24 @@ func (m *mutexKV) get(key string) *sync.Mutex {
 type NexaaClient struct {
 	API NexaaAPI
 	mu  *mutexKV
+
+	// DefaultNamespace is used by namespaced resources that omit the namespace attribute.
+	DefaultNamespace string
+
+	// PollInterval is the initial delay between polls while waiting for a
+	// locked resource. Zero selects the default.
+	PollInterval time.Duration
+
+	// Features holds the cross-resource safety switches of the provider.
+	Features Features
+}
+
+// Features are the switches configured in the features block of the provider.
+type Features struct {
+	// PreventNamespaceDeletionIfContainsResources makes deleting a namespace
+	// fail when it still contains resources, instead of waiting for them to
+	// be removed.
+	PreventNamespaceDeletionIfContainsResources bool
 }
 
 func New(apiClient *api.Client) *NexaaClient {
@@ -0,0 +1,142 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const redacted = "<redacted>"
+
+// sensitiveKeys lists the JSON keys, compared case insensitively and without
+// underscores, whose values are never written to the log.
+var sensitiveKeys = []string{"password", "token", "secret", "accesstoken", "refreshtoken", "authorization", "dsn", "connectionstring"}
+
+// loggingTransport writes every API request and response to the Terraform log
+// at debug level, with credentials redacted.
+type loggingTransport struct {
+	next http.RoundTripper
+	// ctx carries the tflog logger, the API client sends its requests with a
+	// background context that has none.
+	ctx context.Context
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := peekBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	tflog.Debug(t.ctx, "Sending API request", map[string]interface{}{
+		"method": req.Method,
+		"url":    req.URL.String(),
+		"body":   redactBody(reqBody),
+	})
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	duration := time.Since(start).String()
+	if err != nil {
+		tflog.Debug(t.ctx, "API request failed", map[string]interface{}{
+			"url":      req.URL.String(),
+			"duration": duration,
+			"error":    err.Error(),
+		})
+		return resp, err
+	}
+
+	respBody, err := peekBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	tflog.Debug(t.ctx, "Received API response", map[string]interface{}{
+		"url":      req.URL.String(),
+		"status":   resp.StatusCode,
+		"duration": duration,
+		"body":     redactBody(respBody),
+	})
+
+	return resp, nil
+}
+
+// peekBody reads the body and replaces it with a copy so it can still be sent
+// or consumed afterwards.
+func peekBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+
+	data, err := io.ReadAll(*body)
+	_ = (*body).Close()
+	if err != nil {
+		return nil, err
+	}
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
+
+// redactBody returns the body with the values of sensitive JSON keys replaced.
+// Bodies that are not JSON are omitted, as they cannot be redacted reliably.
+func redactBody(data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return "<non-JSON body omitted>"
+	}
+
+	var out bytes.Buffer
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(redactValue(v)); err != nil {
+		return "<body omitted>"
+	}
+	return strings.TrimSuffix(out.String(), "\n")
+}
+
+func redactValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		// Environment variables carry their value next to a secret flag.
+		if secret, ok := val["secret"].(bool); ok && secret && val["value"] != nil {
+			val["value"] = redacted
+		}
+		for k, child := range val {
+			if _, isFlag := child.(bool); isFlag {
+				continue
+			}
+			if isSensitiveKey(k) && child != nil {
+				val[k] = redacted
+				continue
+			}
+			val[k] = redactValue(child)
+		}
+	case []interface{}:
+		for i, child := range val {
+			val[i] = redactValue(child)
+		}
+	}
+	return v
+}
+
+func isSensitiveKey(key string) bool {
+	key = strings.ReplaceAll(strings.ToLower(key), "_", "")
+	for _, s := range sensitiveKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
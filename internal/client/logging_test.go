@@ -0,0 +1,79 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LoggingTransport_logs_redacted_request_and_response(t *testing.T) {
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received = string(body)
+		_, _ = w.Write([]byte(`{"data":{"registry":{"name":"reg","password":"server-secret"}}}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	transport := newTransport(http.DefaultTransport, Options{LogContext: ctx})
+
+	reqBody := `{"query":"mutation","variables":{"input":{"name":"reg","password":"client-secret"}}}`
+	resp := doPost(t, transport, server.URL, reqBody)
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, reqBody, received, "the request body must reach the server unchanged")
+	assert.Contains(t, string(respBody), "server-secret", "the response body must reach the caller unchanged")
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "Sending API request", entries[0]["@message"])
+	assert.Equal(t, "Received API response", entries[1]["@message"])
+	assert.EqualValues(t, http.StatusOK, entries[1]["status"])
+	assert.NotContains(t, output.String(), "client-secret")
+	assert.NotContains(t, output.String(), "server-secret")
+	assert.Contains(t, entries[0]["body"], redacted)
+}
+
+func Test_LoggingTransport_disabled_without_log_context(t *testing.T) {
+	transport := newTransport(http.DefaultTransport, testRetryOptions(0))
+	_, isLogging := transport.(*retryTransport).next.(*loggingTransport)
+	assert.False(t, isLogging)
+}
+
+func Test_RedactBody_nested_keys(t *testing.T) {
+	out := redactBody([]byte(`{"users":[{"name":"admin","Password":"x"}],"accessToken":"y","count":1}`))
+	assert.JSONEq(t, `{"users":[{"name":"admin","Password":"<redacted>"}],"accessToken":"<redacted>","count":1}`, out)
+}
+
+func Test_RedactBody_secret_environment_variables(t *testing.T) {
+	out := redactBody([]byte(`{"environmentVariables":[{"name":"API_KEY","value":"k","secret":true},{"name":"MODE","value":"prod","secret":false}]}`))
+	assert.JSONEq(t, `{"environmentVariables":[{"name":"API_KEY","value":"<redacted>","secret":true},{"name":"MODE","value":"prod","secret":false}]}`, out)
+}
+
+func Test_RedactBody_dsn(t *testing.T) {
+	out := redactBody([]byte(`{"data":{"credentials":{"dsn":"postgres://app:pw@db:5432/app"},"connection_string":"postgres://app:pw@db"}}`))
+	assert.JSONEq(t, `{"data":{"credentials":{"dsn":"<redacted>"},"connection_string":"<redacted>"}}`, out)
+}
+
+func Test_RedactBody_non_json_omitted(t *testing.T) {
+	assert.Equal(t, "<non-JSON body omitted>", redactBody([]byte("password=secret")))
+}
+
+func Test_RedactBody_empty(t *testing.T) {
+	assert.Equal(t, "", redactBody(nil))
+}
@@ -170,6 +170,14 @@ func (m *MockNexaaAPI) CloudDatabaseClusterGet(input api.CloudDatabaseClusterRes
 	return args.Get(0).(api.CloudDatabaseClusterResult), args.Error(1)
 }
 
+func (m *MockNexaaAPI) CloudDatabaseClusterList() ([]api.CloudDatabaseClusterResult, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]api.CloudDatabaseClusterResult), args.Error(1)
+}
+
 func (m *MockNexaaAPI) CloudDatabaseClusterListPlans() ([]api.CloudDatabaseClusterPlan, error) {
 	args := m.Called()
 	if args.Get(0) == nil {
@@ -210,3 +218,8 @@ func (m *MockNexaaAPI) CloudDatabaseClusterUserList(input api.CloudDatabaseClust
 	}
 	return args.Get(0).([]api.CloudDatabaseClusterUserResult), args.Error(1)
 }
+
+func (m *MockNexaaAPI) CloudDatabaseClusterUserCredentials(input api.CloudDatabaseClusterResourceInput, userName string) (string, error) {
+	args := m.Called(input, userName)
+	return args.String(0), args.Error(1)
+}
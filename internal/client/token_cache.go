@@ -0,0 +1,143 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// tokenExpiryMargin keeps tokens that are about to expire out of use.
+	tokenExpiryMargin = time.Minute
+	lockRetryInterval = 50 * time.Millisecond
+	lockTimeout       = 30 * time.Second
+	// staleLockAge is the age after which a lock file left behind by a
+	// crashed process is removed.
+	staleLockAge = time.Minute
+)
+
+// CachedToken is an access token stored in the token cache.
+type CachedToken struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// Valid reports whether the token can still be used for a while.
+func (t CachedToken) Valid(now time.Time) bool {
+	return t.AccessToken != "" && now.Add(tokenExpiryMargin).Before(t.ExpiresAt)
+}
+
+// TokenCache stores access tokens on disk so provider instances that log in
+// with the same account share a session instead of each logging in.
+type TokenCache struct {
+	dir string
+}
+
+// NewTokenCache returns a cache storing its files in dir.
+func NewTokenCache(dir string) *TokenCache {
+	return &TokenCache{dir: dir}
+}
+
+// DefaultTokenCacheDir returns the directory used when none is configured.
+func DefaultTokenCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "terraform-provider-nexaa", "tokens"), nil
+}
+
+// TokenCacheKey identifies the session of username on the authentication
+// server at authURL, without storing the username in the file name.
+func TokenCacheKey(authURL, username string) string {
+	sum := sha256.Sum256([]byte(authURL + "\x00" + username))
+	return hex.EncodeToString(sum[:])
+}
+
+// WithLock runs fn while holding the lock for key, so concurrent provider
+// processes do not log in at the same time for the same account.
+func (c *TokenCache) WithLock(key string, fn func() error) error {
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return err
+	}
+
+	lockFile := filepath.Join(c.dir, key+".lock")
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		f, err := os.OpenFile(lockFile, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			_ = f.Close()
+			break
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return err
+		}
+
+		if info, statErr := os.Stat(lockFile); statErr == nil && time.Since(info.ModTime()) > staleLockAge {
+			_ = os.Remove(lockFile)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for token cache lock %s", lockFile)
+		}
+		time.Sleep(lockRetryInterval)
+	}
+	defer func() { _ = os.Remove(lockFile) }()
+
+	return fn()
+}
+
+// Load returns the cached token for key when it is still valid.
+func (c *TokenCache) Load(key string) (CachedToken, bool) {
+	var token CachedToken
+
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return token, false
+	}
+	if err := json.Unmarshal(data, &token); err != nil {
+		return token, false
+	}
+	return token, token.Valid(time.Now())
+}
+
+// Save stores token for key. The file is replaced atomically so readers never
+// see a partially written token.
+func (c *TokenCache) Save(key string, token CachedToken) error {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(c.dir, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(key))
+}
+
+func (c *TokenCache) path(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
@@ -0,0 +1,106 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TokenCache_save_and_load(t *testing.T) {
+	cache := NewTokenCache(t.TempDir())
+	token := CachedToken{AccessToken: "access", RefreshToken: "refresh", ExpiresAt: time.Now().Add(time.Hour)}
+
+	require.NoError(t, cache.Save("key", token))
+	loaded, ok := cache.Load("key")
+
+	assert.True(t, ok)
+	assert.Equal(t, "access", loaded.AccessToken)
+	assert.Equal(t, "refresh", loaded.RefreshToken)
+}
+
+func Test_TokenCache_ignores_expiring_token(t *testing.T) {
+	cache := NewTokenCache(t.TempDir())
+	require.NoError(t, cache.Save("key", CachedToken{AccessToken: "access", ExpiresAt: time.Now().Add(30 * time.Second)}))
+
+	_, ok := cache.Load("key")
+	assert.False(t, ok)
+}
+
+func Test_TokenCache_missing_or_corrupt_file(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewTokenCache(dir)
+
+	_, ok := cache.Load("missing")
+	assert.False(t, ok)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "corrupt.json"), []byte("{"), 0o600))
+	_, ok = cache.Load("corrupt")
+	assert.False(t, ok)
+}
+
+func Test_TokenCache_file_is_private(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewTokenCache(dir)
+	require.NoError(t, cache.Save("key", CachedToken{AccessToken: "access", ExpiresAt: time.Now().Add(time.Hour)}))
+
+	info, err := os.Stat(filepath.Join(dir, "key.json"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func Test_TokenCache_lock_serializes_callers(t *testing.T) {
+	cache := NewTokenCache(t.TempDir())
+	var inside, peak int32
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := cache.WithLock("key", func() error {
+				if n := atomic.AddInt32(&inside, 1); n > atomic.LoadInt32(&peak) {
+					atomic.StoreInt32(&peak, n)
+				}
+				time.Sleep(5 * time.Millisecond)
+				atomic.AddInt32(&inside, -1)
+				return nil
+			})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&peak))
+}
+
+func Test_TokenCache_removes_stale_lock(t *testing.T) {
+	dir := t.TempDir()
+	lockFile := filepath.Join(dir, "key.lock")
+	require.NoError(t, os.WriteFile(lockFile, nil, 0o600))
+	old := time.Now().Add(-2 * staleLockAge)
+	require.NoError(t, os.Chtimes(lockFile, old, old))
+
+	called := false
+	err := NewTokenCache(dir).WithLock("key", func() error {
+		called = true
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, called)
+	assert.NoFileExists(t, lockFile)
+}
+
+func Test_TokenCacheKey_differs_per_user(t *testing.T) {
+	assert.NotEqual(t, TokenCacheKey("https://auth.tilaa.com", "a"), TokenCacheKey("https://auth.tilaa.com", "b"))
+	assert.Equal(t, TokenCacheKey("https://auth.tilaa.com", "a"), TokenCacheKey("https://auth.tilaa.com", "a"))
+}
@@ -0,0 +1,68 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/nexaa-cloud/terraform-provider-nexaa/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracingTransport records a client span for every API request, named after
+// the GraphQL operation it sends. The API library sends every request with
+// context.Background(), so the span of the resource operation that made the
+// call never reaches the transport. API request spans are therefore separate
+// root spans, to be matched to an operation by time and resource attributes.
+type tracingTransport struct {
+	next http.RoundTripper
+}
+
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := peekBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	name := "nexaa.api"
+	if op := operationName(body); op != "" {
+		name += " " + op
+	}
+
+	ctx, span := tracing.StartSpan(req.Context(), name,
+		trace.WithNewRoot(),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("url.full", req.URL.String()),
+		),
+	)
+	defer span.End()
+
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	return resp, nil
+}
+
+func operationName(body []byte) string {
+	var payload struct {
+		OperationName string `json:"operationName"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return ""
+	}
+	return payload.OperationName
+}
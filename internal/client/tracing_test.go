@@ -0,0 +1,48 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func Test_OperationName_from_graphql_body(t *testing.T) {
+	assert.Equal(t, "getVolume", operationName([]byte(`{"operationName":"getVolume","query":"query getVolume {}"}`)))
+	assert.Equal(t, "", operationName([]byte("not json")))
+}
+
+func Test_TracingTransport_records_root_span(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "nexaa_volume.Read")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, server.URL, strings.NewReader(graphQLQuery))
+	require.NoError(t, err)
+	resp, err := (&tracingTransport{next: http.DefaultTransport}).RoundTrip(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "nexaa.api getVolume", spans[0].Name())
+	assert.False(t, spans[0].Parent().IsValid())
+}
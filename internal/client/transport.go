@@ -0,0 +1,319 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nexaa-cloud/nexaa-cli/api"
+	"github.com/nexaa-cloud/terraform-provider-nexaa/internal/tracing"
+)
+
+// Options configures the HTTP transport used for all Nexaa API calls.
+type Options struct {
+	Retry RetryOptions
+	// MaxConcurrentRequests limits the number of API requests in flight at
+	// the same time. Zero means no limit.
+	MaxConcurrentRequests int
+	// ProxyURL routes all requests through the given proxy. When nil the
+	// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are honoured.
+	ProxyURL *url.URL
+	// RootCAs replaces the system certificate pool used to verify the API
+	// server, for TLS intercepting proxies and private gateways.
+	RootCAs *x509.CertPool
+	// InsecureSkipVerify disables verification of the server certificate.
+	InsecureSkipVerify bool
+	// Credentials enable logging in again when the API rejects the access
+	// token. Without them an expired token fails the request.
+	Credentials *Credentials
+	// UserAgent is sent as the User-Agent header of every request when set.
+	UserAgent string
+	// LogContext carries the tflog logger that requests and responses are
+	// written to. Requests are not logged when it is nil.
+	LogContext context.Context
+}
+
+// RetryOptions configures how failed API requests are retried.
+type RetryOptions struct {
+	// MaxRetries is the number of times a request is retried after the first attempt.
+	MaxRetries int
+	// MinDelay is the delay before the first retry, doubled for every next retry.
+	MinDelay time.Duration
+	// MaxDelay caps the delay between two retries.
+	MaxDelay time.Duration
+}
+
+// DefaultOptions returns the options used when the provider block does not
+// override them.
+func DefaultOptions() Options {
+	return Options{
+		Retry: RetryOptions{
+			MaxRetries: 3,
+			MinDelay:   1 * time.Second,
+			MaxDelay:   30 * time.Second,
+		},
+	}
+}
+
+// NewAPIClient creates an API client whose requests go through a transport
+// configured with opts.
+func NewAPIClient(opts Options) *api.Client {
+	var client *api.Client
+	WithTransport(opts, func() {
+		client = api.NewClient()
+	})
+	return client
+}
+
+// WithTransport runs fn with http.DefaultTransport replaced by a transport
+// configured with opts. The CLI library offers no other way to inject a
+// transport: api.NewClient wraps http.DefaultTransport at construction time
+// and api.Login sends its request with a zero http.Client.
+func WithTransport(opts Options, fn func()) {
+	base := http.DefaultTransport
+	http.DefaultTransport = newTransport(base, opts)
+	defer func() { http.DefaultTransport = base }()
+
+	fn()
+}
+
+func newTransport(base http.RoundTripper, opts Options) http.RoundTripper {
+	next := configureBase(base, opts)
+	if opts.LogContext != nil {
+		next = &loggingTransport{next: next, ctx: opts.LogContext}
+	}
+	if opts.MaxConcurrentRequests > 0 {
+		next = &throttleTransport{next: next, sem: make(chan struct{}, opts.MaxConcurrentRequests)}
+	}
+	if tracing.Enabled() {
+		next = &tracingTransport{next: next}
+	}
+	if opts.UserAgent != "" {
+		next = &userAgentTransport{next: next, userAgent: opts.UserAgent}
+	}
+	if opts.Credentials != nil {
+		next = newAuthTransport(next, *opts.Credentials)
+	}
+	return &retryTransport{next: next, opts: opts.Retry}
+}
+
+// configureBase applies the connection level options to a copy of base.
+func configureBase(base http.RoundTripper, opts Options) http.RoundTripper {
+	if opts.ProxyURL == nil && opts.RootCAs == nil && !opts.InsecureSkipVerify {
+		return base
+	}
+
+	t, ok := base.(*http.Transport)
+	if !ok {
+		return base
+	}
+
+	t = t.Clone()
+	if opts.ProxyURL != nil {
+		t.Proxy = http.ProxyURL(opts.ProxyURL)
+	}
+	if opts.RootCAs != nil || opts.InsecureSkipVerify {
+		if t.TLSClientConfig == nil {
+			t.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		if opts.RootCAs != nil {
+			t.TLSClientConfig.RootCAs = opts.RootCAs
+		}
+		// Only enabled when explicitly requested in the provider configuration.
+		t.TLSClientConfig.InsecureSkipVerify = opts.InsecureSkipVerify
+	}
+	return t
+}
+
+// userAgentTransport identifies the provider to the API.
+type userAgentTransport struct {
+	next      http.RoundTripper
+	userAgent string
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	clone := req.Clone(req.Context())
+	clone.Header.Set("User-Agent", t.userAgent)
+	return t.next.RoundTrip(clone)
+}
+
+// throttleTransport limits the number of concurrent requests, independent of
+// the parallelism Terraform uses to walk the graph. A slot is held from sending
+// a single attempt until its response body is closed, so requests waiting for
+// a retry do not block others.
+type throttleTransport struct {
+	next http.RoundTripper
+	sem  chan struct{}
+}
+
+func (t *throttleTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case t.sem <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+
+	var once sync.Once
+	release := func() { once.Do(func() { <-t.sem }) }
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.Body == nil {
+		release()
+		return resp, err
+	}
+	resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+type releaseOnClose struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releaseOnClose) Close() error {
+	defer b.release()
+	return b.ReadCloser.Close()
+}
+
+// retryTransport retries failed requests using exponential backoff. Every API
+// call is a POST, so a request is only sent again when the server cannot have
+// acted on it: the connection was never made, the request was rate limited,
+// or it is a GraphQL query that only reads. A mutation that failed with a
+// gateway error or a broken connection may have been applied already.
+type retryTransport struct {
+	next http.RoundTripper
+	opts RetryOptions
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	idempotent := t.opts.MaxRetries > 0 && isIdempotentRequest(req)
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.Body != nil {
+			if req.GetBody == nil {
+				return nil, errors.New("unable to retry request: body cannot be rewound")
+			}
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := t.next.RoundTrip(req)
+		if attempt >= t.opts.MaxRetries || !shouldRetry(req, idempotent, resp, err) {
+			return resp, err
+		}
+
+		delay := t.backoff(attempt)
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok && retryAfter > delay {
+				delay = min(retryAfter, t.opts.MaxDelay)
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	delay := t.opts.MinDelay
+	for i := 0; i < attempt && delay < t.opts.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > t.opts.MaxDelay {
+		delay = t.opts.MaxDelay
+	}
+	return delay
+}
+
+// parseRetryAfter parses a Retry-After header, given either in seconds or as
+// an HTTP date. Rate limited responses use it to tell when to try again; the
+// delay is still capped at the maximum retry delay so a misbehaving server
+// cannot stall an apply indefinitely.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if delay := date.Sub(now); delay > 0 {
+			return delay, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+func shouldRetry(req *http.Request, idempotent bool, resp *http.Response, err error) bool {
+	if err != nil {
+		if req.Context().Err() != nil {
+			return false
+		}
+		return idempotent || isDialError(err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent
+	}
+
+	return false
+}
+
+// isDialError reports whether err happened while connecting to the server,
+// before any part of the request was sent.
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// isIdempotentRequest reports whether req can be sent again without side
+// effects: a GET or HEAD request, or a GraphQL query.
+func isIdempotentRequest(req *http.Request) bool {
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return true
+	}
+	if req.GetBody == nil {
+		return false
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return false
+	}
+	defer body.Close()
+
+	var payload struct {
+		Query string `json:"query"`
+	}
+	if err := json.NewDecoder(body).Decode(&payload); err != nil {
+		return false
+	}
+	operation := strings.TrimSpace(payload.Query)
+	return strings.HasPrefix(operation, "query") || strings.HasPrefix(operation, "{")
+}
@@ -0,0 +1,407 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package client
+
+import (
+	"context"
+	"crypto/x509"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testRetryOptions(maxRetries int) Options {
+	return Options{
+		Retry: RetryOptions{
+			MaxRetries: maxRetries,
+			MinDelay:   time.Millisecond,
+			MaxDelay:   5 * time.Millisecond,
+		},
+	}
+}
+
+const (
+	graphQLQuery    = `{"operationName":"getVolume","query":"query getVolume { volume { name } }"}`
+	graphQLMutation = `{"operationName":"createVolume","query":"mutation createVolume { volumeCreate { name } }"}`
+)
+
+func doPost(t *testing.T, transport http.RoundTripper, url string, body string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	assert.NoError(t, err)
+	resp, err := transport.RoundTrip(req)
+	assert.NoError(t, err)
+	return resp
+}
+
+// --- retryTransport ---
+
+func Test_RetryTransport_retries_temporary_errors_with_same_body(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, graphQLQuery, string(body))
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	resp := doPost(t, newTransport(http.DefaultTransport, testRetryOptions(3)), server.URL, graphQLQuery)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func Test_RetryTransport_gives_up_after_max_retries(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	resp := doPost(t, newTransport(http.DefaultTransport, testRetryOptions(2)), server.URL, graphQLQuery)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func Test_RetryTransport_does_not_retry_mutation_after_gateway_error(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusGatewayTimeout)
+	}))
+	defer server.Close()
+
+	resp := doPost(t, newTransport(http.DefaultTransport, testRetryOptions(3)), server.URL, graphQLMutation)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusGatewayTimeout, resp.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func Test_RetryTransport_retries_rate_limited_mutation(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	resp := doPost(t, newTransport(http.DefaultTransport, testRetryOptions(3)), server.URL, graphQLMutation)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func Test_RetryTransport_does_not_retry_mutation_after_broken_connection(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		conn, _, err := w.(http.Hijacker).Hijack()
+		assert.NoError(t, err)
+		_ = conn.Close()
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(graphQLMutation))
+	assert.NoError(t, err)
+	_, err = newTransport(http.DefaultTransport, testRetryOptions(3)).RoundTrip(req)
+
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func Test_ShouldRetry_dial_error(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "http://127.0.0.1", strings.NewReader(graphQLMutation))
+	assert.NoError(t, err)
+	dialErr := &url.Error{Op: "Post", URL: "http://127.0.0.1", Err: &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}}
+	readErr := &url.Error{Op: "Post", URL: "http://127.0.0.1", Err: &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}}
+
+	assert.True(t, shouldRetry(req, false, nil, dialErr))
+	assert.False(t, shouldRetry(req, false, nil, readErr))
+	assert.True(t, shouldRetry(req, true, nil, readErr))
+}
+
+func Test_IsIdempotentRequest(t *testing.T) {
+	tests := map[string]struct {
+		method string
+		body   string
+		want   bool
+	}{
+		"query":     {method: http.MethodPost, body: graphQLQuery, want: true},
+		"shorthand": {method: http.MethodPost, body: `{"query":"{ volumes { name } }"}`, want: true},
+		"mutation":  {method: http.MethodPost, body: graphQLMutation, want: false},
+		"form":      {method: http.MethodPost, body: "grant_type=password", want: false},
+		"get":       {method: http.MethodGet, want: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest(tc.method, "http://127.0.0.1", strings.NewReader(tc.body))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, isIdempotentRequest(req))
+		})
+	}
+}
+
+func Test_RetryTransport_does_not_retry_client_errors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	resp := doPost(t, newTransport(http.DefaultTransport, testRetryOptions(3)), server.URL, "query")
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func Test_RetryTransport_zero_retries_disables_retrying(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	resp := doPost(t, newTransport(http.DefaultTransport, testRetryOptions(0)), server.URL, "query")
+	defer resp.Body.Close()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func Test_RetryTransport_backoff_is_capped(t *testing.T) {
+	rt := &retryTransport{opts: RetryOptions{MinDelay: time.Second, MaxDelay: 5 * time.Second}}
+	assert.Equal(t, time.Second, rt.backoff(0))
+	assert.Equal(t, 2*time.Second, rt.backoff(1))
+	assert.Equal(t, 4*time.Second, rt.backoff(2))
+	assert.Equal(t, 5*time.Second, rt.backoff(3))
+	assert.Equal(t, 5*time.Second, rt.backoff(10))
+}
+
+// --- throttleTransport ---
+
+func Test_ThrottleTransport_limits_concurrent_requests(t *testing.T) {
+	var inFlight, peak int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			old := atomic.LoadInt32(&peak)
+			if current <= old || atomic.CompareAndSwapInt32(&peak, old, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	opts := testRetryOptions(0)
+	opts.MaxConcurrentRequests = 2
+	transport := newTransport(http.DefaultTransport, opts)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp := doPost(t, transport, server.URL, "query")
+			_ = resp.Body.Close()
+		}()
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(2))
+}
+
+func Test_ThrottleTransport_releases_slot_on_body_close(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	opts := testRetryOptions(0)
+	opts.MaxConcurrentRequests = 1
+	transport := newTransport(http.DefaultTransport, opts)
+
+	for i := 0; i < 3; i++ {
+		resp := doPost(t, transport, server.URL, "query")
+		_ = resp.Body.Close()
+	}
+}
+
+func Test_ThrottleTransport_waiting_request_honours_context(t *testing.T) {
+	throttle := &throttleTransport{next: http.DefaultTransport, sem: make(chan struct{}, 1)}
+	throttle.sem <- struct{}{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://127.0.0.1", strings.NewReader("query"))
+	assert.NoError(t, err)
+
+	_, err = throttle.RoundTrip(req)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+// --- proxy ---
+
+func Test_NewTransport_routes_requests_through_proxy(t *testing.T) {
+	var proxied int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&proxied, 1)
+		assert.Equal(t, "api.nexaa.invalid", r.URL.Host)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	proxyURL, err := url.Parse(proxy.URL)
+	assert.NoError(t, err)
+	opts := testRetryOptions(0)
+	opts.ProxyURL = proxyURL
+
+	resp := doPost(t, newTransport(http.DefaultTransport, opts), "http://api.nexaa.invalid/graphql", "query")
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&proxied))
+}
+
+func Test_WithTransport_restores_default_transport(t *testing.T) {
+	before := http.DefaultTransport
+	WithTransport(testRetryOptions(0), func() {
+		assert.NotSame(t, before, http.DefaultTransport)
+	})
+	assert.Same(t, before, http.DefaultTransport)
+}
+
+// --- TLS ---
+
+func Test_NewTransport_trusts_custom_root_cas(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	opts := testRetryOptions(0)
+	opts.RootCAs = pool
+
+	resp := doPost(t, newTransport(http.DefaultTransport, opts), server.URL, "query")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func Test_NewTransport_rejects_unknown_certificate(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("query"))
+	assert.NoError(t, err)
+	_, err = newTransport(http.DefaultTransport, testRetryOptions(0)).RoundTrip(req)
+	assert.Error(t, err)
+}
+
+func Test_NewTransport_insecure_skip_verify(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	opts := testRetryOptions(0)
+	opts.InsecureSkipVerify = true
+
+	resp := doPost(t, newTransport(http.DefaultTransport, opts), server.URL, "query")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+// --- userAgentTransport ---
+
+func Test_NewTransport_sets_user_agent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "terraform-provider-nexaa/test app", r.Header.Get("User-Agent"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	opts := testRetryOptions(0)
+	opts.UserAgent = "terraform-provider-nexaa/test app"
+
+	resp := doPost(t, newTransport(http.DefaultTransport, opts), server.URL, "query")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+// --- Retry-After ---
+
+func Test_RetryTransport_honours_retry_after(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	opts := testRetryOptions(1)
+	opts.Retry.MaxDelay = 2 * time.Second
+	start := time.Now()
+
+	resp := doPost(t, newTransport(http.DefaultTransport, opts), server.URL, "query")
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+}
+
+func Test_ParseRetryAfter_seconds(t *testing.T) {
+	delay, ok := parseRetryAfter("120", time.Now())
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Minute, delay)
+}
+
+func Test_ParseRetryAfter_http_date(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	delay, ok := parseRetryAfter(now.Add(30*time.Second).Format(http.TimeFormat), now)
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, delay)
+}
+
+func Test_ParseRetryAfter_invalid(t *testing.T) {
+	_, ok := parseRetryAfter("soon", time.Now())
+	assert.False(t, ok)
+	_, ok = parseRetryAfter("", time.Now())
+	assert.False(t, ok)
+}
@@ -0,0 +1,355 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package data_sources
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/nexaa-cloud/nexaa-cli/api"
+	nexaaclient "github.com/nexaa-cloud/terraform-provider-nexaa/internal/client"
+	"github.com/nexaa-cloud/terraform-provider-nexaa/internal/enums"
+	"github.com/nexaa-cloud/terraform-provider-nexaa/internal/tracing"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &cloudDatabaseClusterDataSource{}
+	_ datasource.DataSourceWithConfigure = &cloudDatabaseClusterDataSource{}
+)
+
+// NewCloudDatabaseCluster is a helper function to simplify the provider implementation.
+func NewCloudDatabaseCluster() datasource.DataSource {
+	return &cloudDatabaseClusterDataSource{}
+}
+
+type cloudDatabaseClusterDataSource struct {
+	nexaaAPI nexaaclient.NexaaAPI
+}
+
+// cloudDatabaseClusterModel describes a cloud database cluster as read from the API.
+type cloudDatabaseClusterModel struct {
+	Id                 types.String                                 `tfsdk:"id"`
+	Name               types.String                                 `tfsdk:"name"`
+	Namespace          types.String                                 `tfsdk:"namespace"`
+	Spec               *cloudDatabaseClusterSpecModel               `tfsdk:"spec"`
+	Plan               types.String                                 `tfsdk:"plan"`
+	State              types.String                                 `tfsdk:"state"`
+	Hostname           types.String                                 `tfsdk:"hostname"`
+	Port               types.Int64                                  `tfsdk:"port"`
+	PrimaryEndpoint    types.String                                 `tfsdk:"primary_endpoint"`
+	ExternalConnection *cloudDatabaseClusterExternalConnectionModel `tfsdk:"external_connection"`
+	Databases          []cloudDatabaseClusterDatabaseModel          `tfsdk:"databases"`
+	Users              []cloudDatabaseClusterUserModel              `tfsdk:"users"`
+}
+
+type cloudDatabaseClusterSpecModel struct {
+	Type    types.String `tfsdk:"type"`
+	Version types.String `tfsdk:"version"`
+}
+
+type cloudDatabaseClusterExternalConnectionModel struct {
+	Ipv4         types.String `tfsdk:"ipv4"`
+	Ipv6         types.String `tfsdk:"ipv6"`
+	ExternalPort types.Int64  `tfsdk:"external_port"`
+	Allowlist    []string     `tfsdk:"allowlist"`
+}
+
+type cloudDatabaseClusterDatabaseModel struct {
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Status      types.String `tfsdk:"status"`
+}
+
+type cloudDatabaseClusterUserModel struct {
+	Name        types.String                              `tfsdk:"name"`
+	Role        types.String                              `tfsdk:"role"`
+	Status      types.String                              `tfsdk:"status"`
+	Permissions []cloudDatabaseClusterUserPermissionModel `tfsdk:"permissions"`
+}
+
+type cloudDatabaseClusterUserPermissionModel struct {
+	DatabaseName types.String `tfsdk:"database_name"`
+	Permission   types.String `tfsdk:"permission"`
+}
+
+// Metadata returns the data source type name.
+func (d *cloudDatabaseClusterDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cloud_database_cluster"
+}
+
+// Schema defines the schema for the data source.
+func (d *cloudDatabaseClusterDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := cloudDatabaseClusterAttributes()
+	attributes["name"] = schema.StringAttribute{
+		MarkdownDescription: "Name of the cloud database cluster",
+		Required:            true,
+	}
+	attributes["namespace"] = schema.StringAttribute{
+		MarkdownDescription: "Namespace the cloud database cluster belongs to",
+		Required:            true,
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Fetches a cloud database cluster by namespace and name.",
+		Attributes:  attributes,
+	}
+}
+
+// cloudDatabaseClusterAttributes returns the read-only attributes of a cloud
+// database cluster, matching cloudDatabaseClusterModel.
+func cloudDatabaseClusterAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			MarkdownDescription: "Identifier of the cloud database cluster, in the format namespace/name",
+			Computed:            true,
+		},
+		"name": schema.StringAttribute{
+			MarkdownDescription: "Name of the cloud database cluster",
+			Computed:            true,
+		},
+		"namespace": schema.StringAttribute{
+			MarkdownDescription: "Namespace the cloud database cluster belongs to",
+			Computed:            true,
+		},
+		"spec": schema.SingleNestedAttribute{
+			MarkdownDescription: "Cluster specification including type and version",
+			Computed:            true,
+			Attributes: map[string]schema.Attribute{
+				"type": schema.StringAttribute{
+					MarkdownDescription: "Database type, for example PostgreSQL or MySQL",
+					Computed:            true,
+				},
+				"version": schema.StringAttribute{
+					MarkdownDescription: "Database version",
+					Computed:            true,
+				},
+			},
+		},
+		"plan": schema.StringAttribute{
+			MarkdownDescription: "Plan identifier of the cloud database cluster",
+			Computed:            true,
+		},
+		"state": schema.StringAttribute{
+			MarkdownDescription: "Current state of the cloud database cluster",
+			Computed:            true,
+		},
+		"hostname": schema.StringAttribute{
+			MarkdownDescription: "Hostname of the cloud database cluster",
+			Computed:            true,
+		},
+		"port": schema.Int64Attribute{
+			MarkdownDescription: "Port the cloud database cluster listens on within the namespace",
+			Computed:            true,
+		},
+		"primary_endpoint": schema.StringAttribute{
+			MarkdownDescription: "Endpoint of the primary of the cloud database cluster, in the format hostname:port",
+			Computed:            true,
+		},
+		"external_connection": schema.SingleNestedAttribute{
+			MarkdownDescription: "External connection of the cloud database cluster, null when it has none",
+			Computed:            true,
+			Attributes: map[string]schema.Attribute{
+				"ipv4": schema.StringAttribute{
+					MarkdownDescription: "The ipv4 address of the external connection",
+					Computed:            true,
+				},
+				"ipv6": schema.StringAttribute{
+					MarkdownDescription: "The ipv6 address of the external connection",
+					Computed:            true,
+				},
+				"external_port": schema.Int64Attribute{
+					MarkdownDescription: "The port that is used in combination with the ipv4 or ipv6 address",
+					Computed:            true,
+				},
+				"allowlist": schema.ListAttribute{
+					MarkdownDescription: "The IP addresses and ranges that can access the cluster through the external connection",
+					ElementType:         types.StringType,
+					Computed:            true,
+				},
+			},
+		},
+		"databases": schema.ListNestedAttribute{
+			MarkdownDescription: "Databases in the cloud database cluster",
+			Computed:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						MarkdownDescription: "Name of the database",
+						Computed:            true,
+					},
+					"description": schema.StringAttribute{
+						MarkdownDescription: "Description of the database",
+						Computed:            true,
+					},
+					"status": schema.StringAttribute{
+						MarkdownDescription: "Status of the database",
+						Computed:            true,
+					},
+				},
+			},
+		},
+		"users": schema.ListNestedAttribute{
+			MarkdownDescription: "Users of the cloud database cluster. Passwords are not exposed",
+			Computed:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						MarkdownDescription: "Name of the user",
+						Computed:            true,
+					},
+					"role": schema.StringAttribute{
+						MarkdownDescription: "Role of the user",
+						Computed:            true,
+					},
+					"status": schema.StringAttribute{
+						MarkdownDescription: "Status of the user",
+						Computed:            true,
+					},
+					"permissions": schema.ListNestedAttribute{
+						MarkdownDescription: "Permissions of the user on the databases of the cluster",
+						Computed:            true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"database_name": schema.StringAttribute{
+									MarkdownDescription: "Name of the database",
+									Computed:            true,
+								},
+								"permission": schema.StringAttribute{
+									MarkdownDescription: "Permission on the database, read_only or read_write",
+									Computed:            true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure initializes the data source with the API client of the provider.
+func (d *cloudDatabaseClusterDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured
+	if req.ProviderData == nil {
+		return
+	}
+	c, ok := req.ProviderData.(*nexaaclient.NexaaClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Provider Data Type",
+			"Expected *nexaaclient.NexaaClient. Report this issue to the provider developers.")
+		return
+	}
+	d.nexaaAPI = c.API
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *cloudDatabaseClusterDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_cloud_database_cluster.Read")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
+	var data cloudDatabaseClusterModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.nexaaAPI == nil {
+		resp.Diagnostics.AddError("Provider not configured", "The provider has not been configured. Please ensure the provider block is set up correctly.")
+		return
+	}
+
+	cluster, err := d.nexaaAPI.CloudDatabaseClusterGet(api.CloudDatabaseClusterResourceInput{
+		Name:      data.Name.ValueString(),
+		Namespace: data.Namespace.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading cloud database cluster",
+			fmt.Sprintf("Could not read cloud database cluster %q in namespace %q: %s", data.Name.ValueString(), data.Namespace.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	diags := resp.State.Set(ctx, cloudDatabaseClusterFromApi(cluster))
+	resp.Diagnostics.Append(diags...)
+}
+
+// cloudDatabaseClusterFromApi maps a cluster returned by the API onto the data
+// source model. The port is derived from the cluster type and is null for
+// types the provider does not know.
+func cloudDatabaseClusterFromApi(cluster api.CloudDatabaseClusterResult) cloudDatabaseClusterModel {
+	model := cloudDatabaseClusterModel{
+		Id:        types.StringValue(cluster.Namespace.Name + "/" + cluster.Name),
+		Name:      types.StringValue(cluster.Name),
+		Namespace: types.StringValue(cluster.Namespace.Name),
+		Spec: &cloudDatabaseClusterSpecModel{
+			Type:    types.StringValue(cluster.Spec.Type),
+			Version: types.StringValue(cluster.Spec.Version),
+		},
+		Plan:            types.StringValue(cluster.Plan.Id),
+		State:           types.StringValue(cluster.State),
+		Hostname:        types.StringValue(cluster.Hostname),
+		Port:            types.Int64Null(),
+		PrimaryEndpoint: types.StringNull(),
+		Databases:       []cloudDatabaseClusterDatabaseModel{},
+		Users:           []cloudDatabaseClusterUserModel{},
+	}
+
+	if port, ok := enums.DatabasePorts[cluster.Spec.Type]; ok {
+		model.Port = types.Int64Value(port)
+		model.PrimaryEndpoint = types.StringValue(net.JoinHostPort(cluster.Hostname, strconv.FormatInt(port, 10)))
+	}
+
+	if ec := cluster.ExternalConnection; ec != nil {
+		connection := &cloudDatabaseClusterExternalConnectionModel{
+			Ipv4:         types.StringValue(ec.Ipv4),
+			Ipv6:         types.StringValue(ec.Ipv6),
+			ExternalPort: types.Int64Null(),
+			Allowlist:    []string{},
+		}
+		if len(ec.Ports) > 0 {
+			connection.ExternalPort = types.Int64Value(int64(ec.Ports[0].ExternalPort))
+			connection.Allowlist = append(connection.Allowlist, ec.Ports[0].AllowList...)
+		}
+		model.ExternalConnection = connection
+	}
+
+	for _, database := range cluster.Databases {
+		model.Databases = append(model.Databases, cloudDatabaseClusterDatabaseModel{
+			Name:        types.StringValue(database.Name),
+			Description: types.StringPointerValue(database.Description),
+			Status:      types.StringValue(database.Status),
+		})
+	}
+
+	for _, user := range cluster.Users {
+		permissions := []cloudDatabaseClusterUserPermissionModel{}
+		for _, permission := range user.Permissions {
+			value := "read_write"
+			if permission.Permission == api.DatabasePermissionReadOnly {
+				value = "read_only"
+			}
+			permissions = append(permissions, cloudDatabaseClusterUserPermissionModel{
+				DatabaseName: types.StringValue(permission.DatabaseName),
+				Permission:   types.StringValue(value),
+			})
+		}
+		model.Users = append(model.Users, cloudDatabaseClusterUserModel{
+			Name:        types.StringValue(user.Name),
+			Role:        types.StringValue(user.Role),
+			Status:      types.StringValue(user.Status),
+			Permissions: permissions,
+		})
+	}
+
+	return model
+}
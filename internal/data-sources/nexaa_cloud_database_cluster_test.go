@@ -0,0 +1,126 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package data_sources
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/nexaa-cloud/nexaa-cli/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// --- cloudDatabaseClusterFromApi ---
+
+func Test_CloudDatabaseClusterFromApi(t *testing.T) {
+	description := "orders"
+	cluster := api.CloudDatabaseClusterResult{
+		Name:      "db",
+		Hostname:  "db.my-ns",
+		Namespace: api.CloudDatabaseClusterResultNamespace{Name: "my-ns"},
+		Plan:      api.CloudDatabaseClusterResultPlan{Id: "plan-1"},
+		Spec:      api.CloudDatabaseClusterResultSpec{Type: "PostgreSQL", Version: "17.5"},
+		State:     "created",
+		Databases: []api.CloudDatabaseClusterResultDatabasesDatabase{
+			{CloudDatabaseClusterDatabaseResult: api.CloudDatabaseClusterDatabaseResult{Name: "orders", Description: &description, Status: "created"}},
+		},
+		Users: []api.CloudDatabaseClusterResultUsersDatabaseUser{
+			{CloudDatabaseClusterUserResult: api.CloudDatabaseClusterUserResult{
+				Name:     "app",
+				Role:     "user",
+				Status:   "created",
+				Password: "s3cret",
+				Permissions: []api.CloudDatabaseClusterUserResultPermissionsDatabaseUserPermission{
+					{DatabaseName: "orders", Permission: api.DatabasePermissionReadOnly},
+				},
+			}},
+		},
+	}
+
+	model := cloudDatabaseClusterFromApi(cluster)
+
+	assert.Equal(t, "my-ns/db", model.Id.ValueString())
+	assert.Equal(t, "PostgreSQL", model.Spec.Type.ValueString())
+	assert.Equal(t, "plan-1", model.Plan.ValueString())
+	assert.Equal(t, types.Int64Value(5432), model.Port)
+	assert.Equal(t, "db.my-ns:5432", model.PrimaryEndpoint.ValueString())
+	assert.Nil(t, model.ExternalConnection)
+	require.Len(t, model.Databases, 1)
+	assert.Equal(t, "orders", model.Databases[0].Description.ValueString())
+	require.Len(t, model.Users, 1)
+	assert.Equal(t, "app", model.Users[0].Name.ValueString())
+	require.Len(t, model.Users[0].Permissions, 1)
+	assert.Equal(t, "read_only", model.Users[0].Permissions[0].Permission.ValueString())
+}
+
+func Test_CloudDatabaseClusterFromApi_external_connection(t *testing.T) {
+	cluster := api.CloudDatabaseClusterResult{
+		Name:      "db",
+		Namespace: api.CloudDatabaseClusterResultNamespace{Name: "my-ns"},
+		Spec:      api.CloudDatabaseClusterResultSpec{Type: "MySQL", Version: "8.0"},
+		ExternalConnection: &api.CloudDatabaseClusterResultExternalConnection{
+			ExternalConnectionResult: api.ExternalConnectionResult{
+				Ipv4: "192.0.2.10",
+				Ports: []api.ExternalConnectionResultPortsExternalConnectionPort{
+					{ExternalPort: 31000, AllowList: []string{"198.51.100.0/24"}},
+				},
+			},
+		},
+	}
+
+	model := cloudDatabaseClusterFromApi(cluster)
+
+	require.NotNil(t, model.ExternalConnection)
+	assert.Equal(t, "192.0.2.10", model.ExternalConnection.Ipv4.ValueString())
+	assert.Equal(t, types.Int64Value(31000), model.ExternalConnection.ExternalPort)
+	assert.Equal(t, []string{"198.51.100.0/24"}, model.ExternalConnection.Allowlist)
+	assert.Equal(t, types.Int64Value(3306), model.Port)
+}
+
+// --- filterCloudDatabaseClusters ---
+
+func testCluster(namespace, name, specType, state string) api.CloudDatabaseClusterResult {
+	return api.CloudDatabaseClusterResult{
+		Name:      name,
+		Namespace: api.CloudDatabaseClusterResultNamespace{Name: namespace},
+		Spec:      api.CloudDatabaseClusterResultSpec{Type: specType},
+		State:     state,
+	}
+}
+
+func Test_FilterCloudDatabaseClusters_sorted_without_filters(t *testing.T) {
+	clusters := []api.CloudDatabaseClusterResult{
+		testCluster("shop", "orders", "PostgreSQL", "created"),
+		testCluster("blog", "posts", "MySQL", "created"),
+		testCluster("shop", "carts", "MySQL", "creating"),
+	}
+
+	result := filterCloudDatabaseClusters(clusters, types.StringNull(), types.StringNull(), types.StringNull())
+
+	require.Len(t, result, 3)
+	assert.Equal(t, "blog/posts", result[0].Id.ValueString())
+	assert.Equal(t, "shop/carts", result[1].Id.ValueString())
+	assert.Equal(t, "shop/orders", result[2].Id.ValueString())
+}
+
+func Test_FilterCloudDatabaseClusters_applies_every_filter(t *testing.T) {
+	clusters := []api.CloudDatabaseClusterResult{
+		testCluster("shop", "orders", "PostgreSQL", "created"),
+		testCluster("shop", "carts", "MySQL", "created"),
+		testCluster("shop", "sessions", "MySQL", "creating"),
+		testCluster("blog", "posts", "MySQL", "created"),
+	}
+
+	result := filterCloudDatabaseClusters(clusters, types.StringValue("shop"), types.StringValue("MySQL"), types.StringValue("created"))
+
+	require.Len(t, result, 1)
+	assert.Equal(t, "shop/carts", result[0].Id.ValueString())
+}
+
+func Test_FilterCloudDatabaseClusters_no_match_is_empty(t *testing.T) {
+	result := filterCloudDatabaseClusters(nil, types.StringValue("shop"), types.StringNull(), types.StringNull())
+	assert.NotNil(t, result)
+	assert.Empty(t, result)
+}
@@ -0,0 +1,152 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package data_sources
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/nexaa-cloud/nexaa-cli/api"
+	nexaaclient "github.com/nexaa-cloud/terraform-provider-nexaa/internal/client"
+	"github.com/nexaa-cloud/terraform-provider-nexaa/internal/tracing"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &cloudDatabaseClustersDataSource{}
+	_ datasource.DataSourceWithConfigure = &cloudDatabaseClustersDataSource{}
+)
+
+// NewCloudDatabaseClusters is a helper function to simplify the provider implementation.
+func NewCloudDatabaseClusters() datasource.DataSource {
+	return &cloudDatabaseClustersDataSource{}
+}
+
+type cloudDatabaseClustersDataSource struct {
+	nexaaAPI nexaaclient.NexaaAPI
+}
+
+type cloudDatabaseClustersDataSourceModel struct {
+	Id        types.String                `tfsdk:"id"`
+	Namespace types.String                `tfsdk:"namespace"`
+	Type      types.String                `tfsdk:"type"`
+	State     types.String                `tfsdk:"state"`
+	Clusters  []cloudDatabaseClusterModel `tfsdk:"clusters"`
+}
+
+// Metadata returns the data source type name.
+func (d *cloudDatabaseClustersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cloud_database_clusters"
+}
+
+// Schema defines the schema for the data source.
+func (d *cloudDatabaseClustersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the cloud database clusters in a namespace, or in all namespaces when no namespace is set.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the list, the namespace or all when no namespace is set",
+				Computed:            true,
+			},
+			"namespace": schema.StringAttribute{
+				MarkdownDescription: "Only list the clusters in this namespace",
+				Optional:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Only list the clusters of this database type, for example PostgreSQL or MySQL",
+				Optional:            true,
+			},
+			"state": schema.StringAttribute{
+				MarkdownDescription: "Only list the clusters in this state",
+				Optional:            true,
+			},
+			"clusters": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching cloud database clusters, sorted by namespace and name",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: cloudDatabaseClusterAttributes(),
+				},
+			},
+		},
+	}
+}
+
+// Configure initializes the data source with the API client of the provider.
+func (d *cloudDatabaseClustersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured
+	if req.ProviderData == nil {
+		return
+	}
+	c, ok := req.ProviderData.(*nexaaclient.NexaaClient)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Provider Data Type",
+			"Expected *nexaaclient.NexaaClient. Report this issue to the provider developers.")
+		return
+	}
+	d.nexaaAPI = c.API
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *cloudDatabaseClustersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_cloud_database_clusters.Read")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
+	var data cloudDatabaseClustersDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if d.nexaaAPI == nil {
+		resp.Diagnostics.AddError("Provider not configured", "The provider has not been configured. Please ensure the provider block is set up correctly.")
+		return
+	}
+
+	clusters, err := d.nexaaAPI.CloudDatabaseClusterList()
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading cloud database clusters", err.Error())
+		return
+	}
+
+	data.Id = types.StringValue("all")
+	if !data.Namespace.IsNull() {
+		data.Id = types.StringValue(data.Namespace.ValueString())
+	}
+	data.Clusters = filterCloudDatabaseClusters(clusters, data.Namespace, data.Type, data.State)
+
+	diags := resp.State.Set(ctx, data)
+	resp.Diagnostics.Append(diags...)
+}
+
+// filterCloudDatabaseClusters returns the clusters matching every filter that
+// is set, sorted by namespace and name so the list does not change order
+// between reads.
+func filterCloudDatabaseClusters(clusters []api.CloudDatabaseClusterResult, namespace, specType, state types.String) []cloudDatabaseClusterModel {
+	result := []cloudDatabaseClusterModel{}
+	for _, cluster := range clusters {
+		if !namespace.IsNull() && cluster.Namespace.Name != namespace.ValueString() {
+			continue
+		}
+		if !specType.IsNull() && cluster.Spec.Type != specType.ValueString() {
+			continue
+		}
+		if !state.IsNull() && cluster.State != state.ValueString() {
+			continue
+		}
+		result = append(result, cloudDatabaseClusterFromApi(cluster))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace.ValueString() != result[j].Namespace.ValueString() {
+			return result[i].Namespace.ValueString() < result[j].Namespace.ValueString()
+		}
+		return result[i].Name.ValueString() < result[j].Name.ValueString()
+	})
+	return result
+}
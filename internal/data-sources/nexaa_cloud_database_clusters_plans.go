@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/nexaa-cloud/nexaa-cli/api"
 	nexaaclient "github.com/nexaa-cloud/terraform-provider-nexaa/internal/client"
+	"github.com/nexaa-cloud/terraform-provider-nexaa/internal/tracing"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -137,6 +138,9 @@ func (d *cloudDatabasePlansDataSource) Configure(ctx context.Context, req dataso
 
 // Read refreshes the Terraform state with the latest data.
 func (d *cloudDatabasePlansDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_cloud_database_cluster_plans.Read")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var data cloudDatabasePlansDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
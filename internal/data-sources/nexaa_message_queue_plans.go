@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/nexaa-cloud/nexaa-cli/api"
 	nexaaclient "github.com/nexaa-cloud/terraform-provider-nexaa/internal/client"
+	"github.com/nexaa-cloud/terraform-provider-nexaa/internal/tracing"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -91,6 +92,9 @@ func (d *messageQueuePlansDataSource) Configure(ctx context.Context, req datasou
 
 // Read refreshes the Terraform state with the latest data.
 func (d *messageQueuePlansDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_message_queue_plans.Read")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var data messageQueuePlansDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
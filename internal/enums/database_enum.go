@@ -0,0 +1,10 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package enums
+
+// DatabasePorts maps the cloud database cluster types to the port they listen on.
+var DatabasePorts = map[string]int64{
+	"PostgreSQL": 5432,
+	"MySQL":      3306,
+}
@@ -0,0 +1,59 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// commandCredentials are the credentials printed by an auth_command.
+type commandCredentials struct {
+	Token    string `json:"token"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// runAuthCommand runs a credential helper and parses its output. The command
+// prints either a bare token, or a JSON object with a token or a username and
+// password.
+func runAuthCommand(ctx context.Context, argv []string) (commandCredentials, error) {
+	var creds commandCredentials
+	if len(argv) == 0 || argv[0] == "" {
+		return creds, errors.New("auth_command must contain at least the program to run")
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		// Only stderr is included, stdout may already contain a secret.
+		return creds, fmt.Errorf("running %s failed: %w: %s", argv[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	output := strings.TrimSpace(stdout.String())
+	if output == "" {
+		return creds, fmt.Errorf("%s did not print any credentials", argv[0])
+	}
+
+	if !strings.HasPrefix(output, "{") {
+		creds.Token = output
+		return creds, nil
+	}
+
+	if err := json.Unmarshal([]byte(output), &creds); err != nil {
+		return creds, fmt.Errorf("could not parse the JSON printed by %s: %w", argv[0], err)
+	}
+	if creds.Token == "" && (creds.Username == "" || creds.Password == "") {
+		return creds, fmt.Errorf("the JSON printed by %s must contain a token, or a username and password", argv[0])
+	}
+	return creds, nil
+}
@@ -0,0 +1,45 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RunAuthCommand_plain_token(t *testing.T) {
+	creds, err := runAuthCommand(context.Background(), []string{"echo", "  my-token  "})
+	assert.NoError(t, err)
+	assert.Equal(t, "my-token", creds.Token)
+}
+
+func Test_RunAuthCommand_json_username_password(t *testing.T) {
+	creds, err := runAuthCommand(context.Background(), []string{"echo", `{"username":"user@example.com","password":"secret"}`})
+	assert.NoError(t, err)
+	assert.Equal(t, "user@example.com", creds.Username)
+	assert.Equal(t, "secret", creds.Password)
+	assert.Empty(t, creds.Token)
+}
+
+func Test_RunAuthCommand_json_without_credentials(t *testing.T) {
+	_, err := runAuthCommand(context.Background(), []string{"echo", `{"username":"user@example.com"}`})
+	assert.Error(t, err)
+}
+
+func Test_RunAuthCommand_empty_output(t *testing.T) {
+	_, err := runAuthCommand(context.Background(), []string{"true"})
+	assert.Error(t, err)
+}
+
+func Test_RunAuthCommand_failing_command(t *testing.T) {
+	_, err := runAuthCommand(context.Background(), []string{"false"})
+	assert.Error(t, err)
+}
+
+func Test_RunAuthCommand_no_program(t *testing.T) {
+	_, err := runAuthCommand(context.Background(), nil)
+	assert.Error(t, err)
+}
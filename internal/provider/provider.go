@@ -5,7 +5,12 @@ package provider
 
 import (
 	"context"
+	"crypto/x509"
+	"fmt"
+	"net/url"
 	"os"
+	"strings"
+	"time"
 
 	nexaaclient "github.com/nexaa-cloud/terraform-provider-nexaa/internal/client"
 	data_sources "github.com/nexaa-cloud/terraform-provider-nexaa/internal/data-sources"
@@ -14,6 +19,7 @@ import (
 	"github.com/nexaa-cloud/nexaa-cli/api"
 	"github.com/nexaa-cloud/nexaa-cli/config"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
@@ -21,6 +27,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -36,8 +43,39 @@ type NexaaProvider struct {
 
 // NexaaProviderModel describes the provider data model.
 type NexaaProviderModel struct {
-	Username types.String `tfsdk:"username"`
-	Password types.String `tfsdk:"password"`
+	Username    types.String `tfsdk:"username"`
+	Password    types.String `tfsdk:"password"`
+	Token       types.String `tfsdk:"token"`
+	AuthCommand types.List   `tfsdk:"auth_command"`
+
+	CacheToken    types.Bool   `tfsdk:"cache_token"`
+	TokenCacheDir types.String `tfsdk:"token_cache_dir"`
+
+	ApiUrl   types.String `tfsdk:"api_url"`
+	AuthUrl  types.String `tfsdk:"auth_url"`
+	ProxyUrl types.String `tfsdk:"proxy_url"`
+
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+
+	DefaultNamespace types.String `tfsdk:"default_namespace"`
+
+	MaxRetries    types.Int64  `tfsdk:"max_retries"`
+	RetryMinDelay types.String `tfsdk:"retry_min_delay"`
+	RetryMaxDelay types.String `tfsdk:"retry_max_delay"`
+
+	MaxConcurrentRequests types.Int64 `tfsdk:"max_concurrent_requests"`
+
+	PollInterval types.String `tfsdk:"poll_interval"`
+
+	AppName types.String `tfsdk:"app_name"`
+
+	Features *NexaaFeaturesModel `tfsdk:"features"`
+}
+
+// NexaaFeaturesModel describes the features block of the provider.
+type NexaaFeaturesModel struct {
+	PreventDeletionIfContainsResources types.Bool `tfsdk:"prevent_deletion_if_contains_resources"`
 }
 
 func (p *NexaaProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -54,13 +92,102 @@ on delivering and not on managing your infrastructure. That is why we made it ea
 `,
 		Attributes: map[string]schema.Attribute{
 			"username": schema.StringAttribute{
-				Required:    true,
-				Description: "The username used to log in the API account",
+				Optional:    true,
+				Description: "The username used to log in the API account. Can also be set with the NEXAA_USERNAME environment variable",
 			},
 			"password": schema.StringAttribute{
-				Required:    true,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "The password used to log in the API account. Can also be set with the NEXAA_PASSWORD environment variable",
+			},
+			"token": schema.StringAttribute{
+				Optional:    true,
 				Sensitive:   true,
-				Description: "The password used to log in the API account",
+				Description: "A long-lived API token used instead of username and password. Can also be set with the NEXAA_TOKEN environment variable",
+			},
+			"api_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "The URL of the Nexaa GraphQL API, used to target staging or on-premise deployments. Can also be set with the NEXAA_GRAPHQL_URL environment variable. Defaults to https://graphql.tilaa.com/graphql/platform",
+			},
+			"app_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "A name appended to the User-Agent of all API requests, for example the name of the Terraform workspace, so the traffic can be attributed in the Nexaa audit logs",
+			},
+			"auth_command": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "A command, as the program followed by its arguments, that prints the credentials on stdout. This lets secrets come from a vault CLI or password manager. The output is either a token, or a JSON object with a token or a username and password. Credentials set in the configuration or environment take precedence",
+			},
+			"auth_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "The URL of the authentication server used to log in with username and password. Can also be set with the NEXAA_KEYCLOAK_URL environment variable. Defaults to https://auth.tilaa.com",
+			},
+			"poll_interval": schema.StringAttribute{
+				Optional:    true,
+				Description: "The delay between two checks while waiting for a locked resource, as a duration like \"5s\". The delay doubles after every check up to 15s, or up to poll_interval when that is larger. Defaults to 2s",
+			},
+			"proxy_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "The URL of an HTTP, HTTPS or SOCKS5 proxy used for all requests to the Nexaa API. When omitted the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are used",
+			},
+			"cache_token": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Cache the session obtained with username and password on disk and share it between provider instances, so repeated plans do not log in every time. Defaults to false",
+			},
+			"token_cache_dir": schema.StringAttribute{
+				Optional:    true,
+				Description: "The directory the session is cached in when cache_token is enabled. Defaults to terraform-provider-nexaa/tokens in the user cache directory",
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to a PEM encoded CA bundle used instead of the system certificates to verify the API server, for TLS intercepting proxies or private gateways",
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Skip verification of the TLS certificate of the API server. Only use this for testing, it makes the connection vulnerable to interception. Defaults to false",
+			},
+			"default_namespace": schema.StringAttribute{
+				Optional:    true,
+				Description: "The namespace used by namespaced resources that do not set the namespace attribute themselves",
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The maximum number of API requests the provider sends at the same time, regardless of the parallelism of Terraform. Use this to stay below the rate limits of the API during large applies. Defaults to no limit",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional:    true,
+				Description: "The maximum number of times an API request is retried when it could not connect, was rate limited, or is a read that failed with a network error or a temporary server error. Changes are not retried once they may have reached the API. Defaults to 3, set to 0 to disable retries",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_min_delay": schema.StringAttribute{
+				Optional:    true,
+				Description: "The delay before the first retry of a failed API request, as a duration like \"1s\". The delay doubles for every next retry. Defaults to 1s",
+				Validators: []validator.String{
+					positiveDurationValidator{},
+				},
+			},
+			"retry_max_delay": schema.StringAttribute{
+				Optional:    true,
+				Description: "The maximum delay between two retries of a failed API request, as a duration like \"30s\". Defaults to 30s. Rate limited requests wait as long as their Retry-After header asks, up to this delay",
+				Validators: []validator.String{
+					positiveDurationValidator{},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"features": schema.SingleNestedBlock{
+				Description: "Switches for safety behaviour that applies across resources",
+				Attributes: map[string]schema.Attribute{
+					"prevent_deletion_if_contains_resources": schema.BoolAttribute{
+						Optional:    true,
+						Description: "Fail when deleting a namespace that still contains resources, such as resources created outside of Terraform, instead of waiting for them to be removed. Resources deleted earlier in the same apply get one minute to disappear. Defaults to false",
+					},
+				},
 			},
 		},
 	}
@@ -90,12 +217,77 @@ func (p *NexaaProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		)
 	}
 
+	if conf.Token.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("token"),
+			"Unknown token",
+			"The API token must be known when the provider is configured",
+		)
+	}
+
+	if conf.AuthCommand.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("auth_command"),
+			"Unknown auth command",
+			"The auth command must be known when the provider is configured",
+		)
+	}
+
+	if conf.ApiUrl.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_url"),
+			"Unknown API URL",
+			"The API URL must be known when the provider is configured",
+		)
+	}
+
+	if conf.AuthUrl.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("auth_url"),
+			"Unknown authentication URL",
+			"The authentication URL must be known when the provider is configured",
+		)
+	}
+
+	if conf.ProxyUrl.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("proxy_url"),
+			"Unknown proxy URL",
+			"The proxy URL must be known when the provider is configured",
+		)
+	}
+
+	if conf.CACertFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_cert_file"),
+			"Unknown CA certificate file",
+			"The CA certificate file must be known when the provider is configured",
+		)
+	}
+
+	if conf.InsecureSkipVerify.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("insecure_skip_verify"),
+			"Unknown insecure_skip_verify",
+			"insecure_skip_verify must be known when the provider is configured",
+		)
+	}
+
+	if conf.DefaultNamespace.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_namespace"),
+			"Unknown default namespace",
+			"The default namespace must be known when the provider is configured",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	username := os.Getenv("NEXAA_USERNAME")
 	password := os.Getenv("NEXAA_PASSWORD")
+	token := os.Getenv("NEXAA_TOKEN")
 
 	if !conf.Username.IsNull() {
 		username = conf.Username.ValueString()
@@ -105,19 +297,126 @@ func (p *NexaaProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		password = conf.Password.ValueString()
 	}
 
-	if username == "" {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("username"),
-			"Unknown username",
-			"Missing username for authentication",
+	if !conf.Token.IsNull() {
+		token = conf.Token.ValueString()
+	}
+
+	if !conf.AuthCommand.IsNull() && token == "" && (username == "" || password == "") {
+		var argv []string
+		resp.Diagnostics.Append(conf.AuthCommand.ElementsAs(ctx, &argv, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		creds, err := runAuthCommand(ctx, argv)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("auth_command"), "Unable to get credentials", err.Error())
+			return
+		}
+
+		if creds.Token != "" {
+			token = creds.Token
+		} else {
+			username = creds.Username
+			password = creds.Password
+		}
+	}
+
+	// A token takes precedence over username and password, so the credentials
+	// are only required when no token is available.
+	if token == "" {
+		if username == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("username"),
+				"Unknown username",
+				"Missing username for authentication, set username and password, token or auth_command",
+			)
+		}
+
+		if password == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("password"),
+				"Unknown password",
+				"Missing password for authentication, set username and password, token or auth_command",
+			)
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := nexaaclient.DefaultOptions()
+	// The logger of the configure request stays usable afterwards, so API calls
+	// made by resources and data sources are logged through it.
+	opts.LogContext = ctx
+	opts.UserAgent = userAgent(req.TerraformVersion, p.version, conf.AppName.ValueString())
+
+	if !conf.ProxyUrl.IsNull() {
+		proxy, err := parseProxyURL(conf.ProxyUrl.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("proxy_url"), "Invalid proxy URL", err.Error())
+		}
+		opts.ProxyURL = proxy
+	}
+
+	if !conf.CACertFile.IsNull() {
+		pool, err := loadCACertFile(conf.CACertFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("ca_cert_file"), "Invalid CA certificate file", err.Error())
+		}
+		opts.RootCAs = pool
+	}
+
+	if conf.InsecureSkipVerify.ValueBool() {
+		opts.InsecureSkipVerify = true
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("insecure_skip_verify"),
+			"TLS verification disabled",
+			"The certificate of the Nexaa API is not verified, only use insecure_skip_verify for testing",
 		)
 	}
 
-	if password == "" {
+	if !conf.MaxConcurrentRequests.IsNull() && !conf.MaxConcurrentRequests.IsUnknown() {
+		opts.MaxConcurrentRequests = int(conf.MaxConcurrentRequests.ValueInt64())
+	}
+
+	if !conf.MaxRetries.IsNull() && !conf.MaxRetries.IsUnknown() {
+		opts.Retry.MaxRetries = int(conf.MaxRetries.ValueInt64())
+	}
+
+	if !conf.RetryMinDelay.IsNull() && !conf.RetryMinDelay.IsUnknown() {
+		delay, err := time.ParseDuration(conf.RetryMinDelay.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("retry_min_delay"), "Invalid retry delay", err.Error())
+		}
+		opts.Retry.MinDelay = delay
+	}
+
+	var pollInterval time.Duration
+	if !conf.PollInterval.IsNull() && !conf.PollInterval.IsUnknown() {
+		interval, err := time.ParseDuration(conf.PollInterval.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("poll_interval"), "Invalid poll interval", err.Error())
+		} else if interval <= 0 {
+			resp.Diagnostics.AddAttributeError(path.Root("poll_interval"), "Invalid poll interval", "poll_interval must be larger than zero")
+		}
+		pollInterval = interval
+	}
+
+	if !conf.RetryMaxDelay.IsNull() && !conf.RetryMaxDelay.IsUnknown() {
+		delay, err := time.ParseDuration(conf.RetryMaxDelay.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("retry_max_delay"), "Invalid retry delay", err.Error())
+		}
+		opts.Retry.MaxDelay = delay
+	}
+
+	if opts.Retry.MinDelay > opts.Retry.MaxDelay {
 		resp.Diagnostics.AddAttributeError(
-			path.Root("password"),
-			"Unknown password",
-			"Missing password for authentication",
+			path.Root("retry_min_delay"),
+			"Invalid retry delay",
+			fmt.Sprintf("retry_min_delay (%s) must not be larger than retry_max_delay (%s)", opts.Retry.MinDelay, opts.Retry.MaxDelay),
 		)
 	}
 
@@ -127,6 +426,26 @@ func (p *NexaaProvider) Configure(ctx context.Context, req provider.ConfigureReq
 
 	config.Initialize()
 
+	// The CLI library reads its endpoints from package level configuration,
+	// so overriding them here applies to every client created below.
+	if !conf.ApiUrl.IsNull() {
+		if err := validateEndpointURL(conf.ApiUrl.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("api_url"), "Invalid API URL", err.Error())
+		}
+		config.GRAPHQL_URL = conf.ApiUrl.ValueString()
+	}
+
+	if !conf.AuthUrl.IsNull() {
+		if err := validateEndpointURL(conf.AuthUrl.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("auth_url"), "Invalid authentication URL", err.Error())
+		}
+		config.KEYCLOAK_URL = strings.TrimSuffix(conf.AuthUrl.ValueString(), "/")
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	if err := config.LoadConfig(); err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to load Nexaa configuration",
@@ -135,20 +454,70 @@ func (p *NexaaProvider) Configure(ctx context.Context, req provider.ConfigureReq
 		return
 	}
 
-	err := api.Login(username, password)
+	if token != "" {
+		// Validate the token once with a lightweight query, every client
+		// created below reuses it for all resource operations.
+		config.AccessToken = token
+		var err error
+		nexaaclient.WithTransport(opts, func() {
+			_, err = api.GetAccountId()
+		})
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("token"),
+				"Invalid token",
+				"Unable to authenticate with the provided API token. Error: "+err.Error(),
+			)
+			return
+		}
+	} else if conf.CacheToken.ValueBool() {
+		dir := conf.TokenCacheDir.ValueString()
+		if dir == "" {
+			var err error
+			dir, err = nexaaclient.DefaultTokenCacheDir()
+			if err != nil {
+				resp.Diagnostics.AddError("Unable to locate the token cache", "Error: "+err.Error())
+				return
+			}
+		}
 
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to log in",
-			"Error: "+err.Error(),
-		)
-		return
+		if err := loginWithTokenCache(nexaaclient.NewTokenCache(dir), opts, username, password); err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to log in",
+				"Error: "+err.Error(),
+			)
+			return
+		}
+	} else {
+		var err error
+		nexaaclient.WithTransport(opts, func() {
+			err = api.Login(username, password)
+		})
+
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to log in",
+				"Error: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	if token == "" {
+		// Log in again with the same credentials when the access token
+		// expires during a long running apply.
+		opts.Credentials = &nexaaclient.Credentials{Username: username, Password: password}
 	}
 
 	// Create API client and make it available to resources.
 	// NexaaClient wraps the client with a shared MutexKV that serializes
 	// concurrent Create calls for the same resource name.
-	client := nexaaclient.New(api.NewClient())
+	client := nexaaclient.New(nexaaclient.NewAPIClient(opts))
+	client.DefaultNamespace = conf.DefaultNamespace.ValueString()
+	client.PollInterval = pollInterval
+	if conf.Features != nil {
+		client.Features.PreventNamespaceDeletionIfContainsResources = conf.Features.PreventDeletionIfContainsResources.ValueBool()
+	}
 	resp.ResourceData = client
 	resp.DataSourceData = client
 }
@@ -174,7 +543,9 @@ func (p *NexaaProvider) EphemeralResources(ctx context.Context) []func() ephemer
 
 func (p *NexaaProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		data_sources.NewCloudDatabaseCluster,
 		data_sources.NewCloudDatabaseClusterPlans,
+		data_sources.NewCloudDatabaseClusters,
 		data_sources.NewContainerResources,
 		data_sources.NewMessageQueuePlans,
 	}
@@ -184,6 +555,87 @@ func (p *NexaaProvider) Functions(ctx context.Context) []func() function.Functio
 	return []func() function.Function{}
 }
 
+// validateEndpointURL checks that an endpoint override is an absolute http(s) URL.
+func validateEndpointURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("could not parse %q: %w", raw, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("expected an absolute http or https URL, got: %s", raw)
+	}
+	return nil
+}
+
+// userAgent identifies the provider and, when set, the application managing
+// the resources in the User-Agent header.
+func userAgent(terraformVersion, providerVersion, appName string) string {
+	ua := fmt.Sprintf("Terraform/%s terraform-provider-nexaa/%s", terraformVersion, providerVersion)
+	if appName != "" {
+		ua += " " + appName
+	}
+	return ua
+}
+
+// loginWithTokenCache reuses the cached session of username when it is still
+// valid, and otherwise logs in and caches the new session. The cache is locked
+// meanwhile, so concurrent provider instances log in only once.
+func loginWithTokenCache(cache *nexaaclient.TokenCache, opts nexaaclient.Options, username, password string) error {
+	key := nexaaclient.TokenCacheKey(config.KEYCLOAK_URL, username)
+
+	return cache.WithLock(key, func() error {
+		if cached, ok := cache.Load(key); ok {
+			config.AccessToken = cached.AccessToken
+			config.RefreshToken = cached.RefreshToken
+			config.ExpiresAt = cached.ExpiresAt.UnixMicro()
+			return nil
+		}
+
+		var err error
+		nexaaclient.WithTransport(opts, func() {
+			err = api.Login(username, password)
+		})
+		if err != nil {
+			return err
+		}
+
+		// api.Login stores the expiry in microseconds.
+		return cache.Save(key, nexaaclient.CachedToken{
+			AccessToken:  config.AccessToken,
+			RefreshToken: config.RefreshToken,
+			ExpiresAt:    time.UnixMicro(config.ExpiresAt),
+		})
+	})
+}
+
+func parseProxyURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse %q: %w", raw, err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("expected an http, https or socks5 URL, got: %s", raw)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("expected an absolute URL with a host, got: %s", raw)
+	}
+	return u, nil
+}
+
+func loadCACertFile(file string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("could not read %s: %w", file, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no PEM encoded certificates found in %s", file)
+	}
+	return pool, nil
+}
+
 func New(version string) func() provider.Provider {
 	return func() provider.Provider {
 		return &NexaaProvider{
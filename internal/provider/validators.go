@@ -0,0 +1,40 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// positiveDurationValidator checks that a string attribute holds a Go duration
+// larger than zero, such as "500ms" or "30s".
+type positiveDurationValidator struct{}
+
+func (v positiveDurationValidator) Description(_ context.Context) string {
+	return "Value must be a duration larger than zero, like \"1s\" or \"500ms\"."
+}
+
+func (v positiveDurationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v positiveDurationValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	raw := req.ConfigValue.ValueString()
+	delay, err := time.ParseDuration(raw)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid duration", fmt.Sprintf("Could not parse %q as a duration like \"1s\": %s", raw, err))
+		return
+	}
+	if delay <= 0 {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid duration", fmt.Sprintf("The duration must be larger than zero, got: %s", raw))
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func runPositiveDurationValidator(value types.String) validator.StringResponse {
+	req := validator.StringRequest{Path: path.Root("retry_min_delay"), ConfigValue: value}
+	var resp validator.StringResponse
+	positiveDurationValidator{}.ValidateString(context.Background(), req, &resp)
+	return resp
+}
+
+func Test_PositiveDuration_valid(t *testing.T) {
+	for _, value := range []string{"1s", "500ms", "1m30s"} {
+		resp := runPositiveDurationValidator(types.StringValue(value))
+		assert.False(t, resp.Diagnostics.HasError(), value)
+	}
+}
+
+func Test_PositiveDuration_invalid(t *testing.T) {
+	for _, value := range []string{"", "soon", "10", "0s", "-1s"} {
+		resp := runPositiveDurationValidator(types.StringValue(value))
+		assert.True(t, resp.Diagnostics.HasError(), value)
+	}
+}
+
+func Test_PositiveDuration_null_and_unknown(t *testing.T) {
+	assert.False(t, runPositiveDurationValidator(types.StringNull()).Diagnostics.HasError())
+	assert.False(t, runPositiveDurationValidator(types.StringUnknown()).Diagnostics.HasError())
+}
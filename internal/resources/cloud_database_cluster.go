@@ -7,6 +7,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
@@ -15,9 +17,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/nexaa-cloud/nexaa-cli/api"
+	"github.com/nexaa-cloud/terraform-provider-nexaa/internal/enums"
 )
 
-func translateApiToCloudDatabaseClusterResource(ctx context.Context, cluster api.CloudDatabaseClusterResult, timeout timeouts.Value) (cloudDatabaseClusterResource, diag.Diagnostics) {
+// translateApiToCloudDatabaseClusterResource maps a cluster returned by the API
+// onto the resource. deletionProtection and timeout are not known to the API
+// and are carried over from the plan or state.
+func translateApiToCloudDatabaseClusterResource(ctx context.Context, cluster api.CloudDatabaseClusterResult, deletionProtection types.Bool, timeout timeouts.Value) (cloudDatabaseClusterResource, diag.Diagnostics) {
 	plan := cloudDatabaseClusterResource{}
 
 	namespace := cluster.GetNamespace()
@@ -27,6 +33,12 @@ func translateApiToCloudDatabaseClusterResource(ctx context.Context, cluster api
 		Namespace: types.StringValue(namespace.GetName()),
 	}
 	plan.Hostname = types.StringValue(cluster.Hostname)
+	plan.Port = types.Int64Null()
+	plan.PrimaryEndpoint = types.StringNull()
+	if port, ok := enums.DatabasePorts[cluster.Spec.GetType()]; ok {
+		plan.Port = types.Int64Value(port)
+		plan.PrimaryEndpoint = types.StringValue(net.JoinHostPort(cluster.Hostname, strconv.FormatInt(port, 10)))
+	}
 	plan.Plan = types.StringValue(cluster.Plan.GetId())
 	plan.Spec = Spec{
 		Type:    types.StringValue(cluster.Spec.GetType()),
@@ -34,6 +46,7 @@ func translateApiToCloudDatabaseClusterResource(ctx context.Context, cluster api
 	}
 
 	plan.State = types.StringValue(cluster.GetState())
+	plan.DeletionProtection = types.BoolValue(deletionProtection.ValueBool())
 	plan.Timeouts = timeout
 
 	if cluster.GetExternalConnection() == nil {
@@ -139,3 +139,34 @@ func Test_TranslatePlanToUserModifyInput_new_permission_added(t *testing.T) {
 	assert.Equal(t, api.StatePresent, perms[0].State)
 	assert.Equal(t, api.DatabasePermissionReadOnly, perms[0].Permission)
 }
+
+// --- translateApiToCloudDatabaseClusterResource ---
+
+func Test_TranslateApiToCloudDatabaseClusterResource_endpoint(t *testing.T) {
+	cluster := api.CloudDatabaseClusterResult{
+		Name:      "my-cluster",
+		Hostname:  "my-cluster.my-ns",
+		Namespace: api.CloudDatabaseClusterResultNamespace{Name: "my-ns"},
+		Spec:      api.CloudDatabaseClusterResultSpec{Type: "PostgreSQL", Version: "17.5"},
+	}
+
+	plan, diags := translateApiToCloudDatabaseClusterResource(context.Background(), cluster, types.BoolNull(), cloudDBClusterTimeouts())
+
+	assert.False(t, diags.HasError())
+	assert.Equal(t, types.Int64Value(5432), plan.Port)
+	assert.Equal(t, types.StringValue("my-cluster.my-ns:5432"), plan.PrimaryEndpoint)
+}
+
+func Test_TranslateApiToCloudDatabaseClusterResource_unknown_type_has_no_endpoint(t *testing.T) {
+	cluster := api.CloudDatabaseClusterResult{
+		Name:     "my-cluster",
+		Hostname: "my-cluster.my-ns",
+		Spec:     api.CloudDatabaseClusterResultSpec{Type: "Redis", Version: "7"},
+	}
+
+	plan, diags := translateApiToCloudDatabaseClusterResource(context.Background(), cluster, types.BoolNull(), cloudDBClusterTimeouts())
+
+	assert.False(t, diags.HasError())
+	assert.True(t, plan.Port.IsNull())
+	assert.True(t, plan.PrimaryEndpoint.IsNull())
+}
@@ -0,0 +1,112 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// commandEnvReferences returns the names of the environment variables an
+// argument refers to with $(NAME). A reference escaped as $$(NAME) is left
+// alone, as it is passed to the container literally.
+func commandEnvReferences(arg string) []string {
+	var names []string
+	for i := 0; i < len(arg)-1; i++ {
+		if arg[i] != '$' {
+			continue
+		}
+		if arg[i+1] == '$' {
+			i++
+			continue
+		}
+		if arg[i+1] != '(' {
+			continue
+		}
+		end := strings.IndexByte(arg[i+2:], ')')
+		if end < 0 {
+			break
+		}
+		name := arg[i+2 : i+2+end]
+		if isEnvVarName(name) {
+			names = append(names, name)
+		}
+		i += 2 + end
+	}
+	return names
+}
+
+func isEnvVarName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, c := range name {
+		letter := c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+		if !letter && (i == 0 || c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// configuredEnvNames returns the names of every environment variable set in
+// the configuration. The second result is false when a name is not known
+// yet, in which case references cannot be checked.
+func configuredEnvNames(ctx context.Context, env types.Set, writeOnly types.Map, envFrom types.List) (map[string]bool, bool) {
+	if env.IsUnknown() || writeOnly.IsUnknown() || envFrom.IsUnknown() {
+		return nil, false
+	}
+
+	names := envFromNames(ctx, envFrom)
+	if !env.IsNull() {
+		var envs []environmentVariableResource
+		if diags := env.ElementsAs(ctx, &envs, false); diags.HasError() {
+			return nil, false
+		}
+		for _, ev := range envs {
+			if ev.Name.IsUnknown() {
+				return nil, false
+			}
+			names[ev.Name.ValueString()] = true
+		}
+	}
+	for name := range writeOnly.Elements() {
+		names[name] = true
+	}
+	return names, true
+}
+
+// warnUndefinedCommandEnv warns about $(NAME) references in a command or
+// entrypoint list to variables that are not configured, so a typo shows up
+// in the plan instead of when the container starts.
+func warnUndefinedCommandEnv(ctx context.Context, attribute string, list types.List, names map[string]bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if list.IsNull() || list.IsUnknown() {
+		return diags
+	}
+
+	for i, elem := range list.Elements() {
+		arg, ok := elem.(types.String)
+		if !ok || arg.IsNull() || arg.IsUnknown() {
+			continue
+		}
+		for _, name := range commandEnvReferences(arg.ValueString()) {
+			if names[name] {
+				continue
+			}
+			diags.AddAttributeWarning(
+				path.Root(attribute).AtListIndex(i),
+				"Undefined environment variable",
+				fmt.Sprintf("$(%s) refers to an environment variable that is not configured, so it will not be replaced. "+
+					"Add %s to environment_variables, or write $$(%s) to pass the text literally.", name, name, name),
+			)
+		}
+	}
+	return diags
+}
@@ -0,0 +1,72 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CommandEnvReferences(t *testing.T) {
+	tests := map[string]struct {
+		arg  string
+		want []string
+	}{
+		"none":         {arg: "echo hello", want: nil},
+		"single":       {arg: "$(DATABASE_URL)", want: []string{"DATABASE_URL"}},
+		"embedded":     {arg: "--url=$(HOST):$(PORT)/db", want: []string{"HOST", "PORT"}},
+		"escaped":      {arg: "$$(HOST) $(PORT)", want: []string{"PORT"}},
+		"shell braces": {arg: "${HOST} $HOST", want: nil},
+		"invalid name": {arg: "$(date +%s) $(1ST)", want: nil},
+		"unterminated": {arg: "$(HOST", want: nil},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, commandEnvReferences(tc.arg))
+		})
+	}
+}
+
+func Test_ConfiguredEnvNames(t *testing.T) {
+	writeOnly := types.MapValueMust(types.StringType, map[string]attr.Value{"TOKEN": types.StringValue("s3cret")})
+
+	names, known := configuredEnvNames(context.Background(), envSet(envVar("MODE", "batch", false)), writeOnly, types.ListNull(envFromObjectType()))
+
+	assert.True(t, known)
+	assert.Equal(t, map[string]bool{"MODE": true, "TOKEN": true}, names)
+}
+
+func Test_ConfiguredEnvNames_unknown_environment(t *testing.T) {
+	_, known := configuredEnvNames(context.Background(), types.SetUnknown(envVarObjectType()), types.MapNull(types.StringType), types.ListNull(envFromObjectType()))
+	assert.False(t, known)
+}
+
+func Test_WarnUndefinedCommandEnv(t *testing.T) {
+	command := types.ListValueMust(types.StringType, []attr.Value{
+		types.StringValue("migrate"),
+		types.StringValue("--url=$(DATABSE_URL)"),
+		types.StringValue("--mode=$(MODE)"),
+	})
+
+	diags := warnUndefinedCommandEnv(context.Background(), "command", command, map[string]bool{"MODE": true, "DATABASE_URL": true})
+
+	require.False(t, diags.HasError())
+	require.Len(t, diags.Warnings(), 1)
+	assert.Contains(t, diags.Warnings()[0].Detail(), "$(DATABSE_URL)")
+	withPath, ok := diags.Warnings()[0].(interface{ Path() path.Path })
+	require.True(t, ok)
+	assert.Equal(t, path.Root("command").AtListIndex(1), withPath.Path())
+}
+
+func Test_WarnUndefinedCommandEnv_null_list(t *testing.T) {
+	diags := warnUndefinedCommandEnv(context.Background(), "entrypoint", types.ListNull(types.StringType), nil)
+	assert.Empty(t, diags)
+}
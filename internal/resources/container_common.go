@@ -11,6 +11,9 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
@@ -119,14 +122,9 @@ func buildMountsInput(ctx context.Context, mounts types.List) ([]api.MountInput,
 	var mountInputs []api.MountInput
 	for _, m := range mountsData {
 		mountInputs = append(mountInputs, api.MountInput{
-			Path: m.Path.ValueString(),
-			Volume: api.MountVolumeInput{
-				Name:       m.Volume.ValueString(),
-				AutoCreate: false,
-				Increase:   false,
-				Size:       nil,
-			},
-			State: api.StatePresent,
+			Path:   m.Path.ValueString(),
+			Volume: buildMountVolumeInput(m, nil),
+			State:  api.StatePresent,
 		})
 	}
 
@@ -149,7 +147,7 @@ func buildIngressesInput(ctx context.Context, ingresses types.List) ([]api.Ingre
 	var ingressInputs []api.IngressInput
 	for _, ing := range ingressesData {
 		if !ing.Port.IsNull() {
-			allowList := toStringArray(ctx, ing.AllowList)
+			allowList := setToStringArray(ctx, ing.AllowList)
 			var domainPtr *string
 			if !ing.DomainName.IsNull() && !ing.DomainName.IsUnknown() {
 				domain := ing.DomainName.ValueString()
@@ -189,6 +187,29 @@ func buildHealthCheckInput(ctx context.Context, healthCheck types.Object) (*api.
 	}, diags
 }
 
+// buildCommandResetInput converts a command or entrypoint list into API input
+// that keeps an omitted list apart from an empty one. A null list becomes nil,
+// which the API takes as a reset to the default of the image, while an empty
+// list is sent as is.
+func buildCommandResetInput(ctx context.Context, list types.List) ([]string, diag.Diagnostics) {
+	if list.IsNull() || list.IsUnknown() {
+		return nil, nil
+	}
+	values := make([]string, 0, len(list.Elements()))
+	diags := list.ElementsAs(ctx, &values, false)
+	return values, diags
+}
+
+// keepEmptyList returns the known empty list from config instead of result.
+// The API does not tell an empty command or entrypoint apart from an omitted
+// one, so the empty list would otherwise be lost from state.
+func keepEmptyList(result, known types.List) types.List {
+	if result.IsNull() && !known.IsNull() && !known.IsUnknown() && len(known.Elements()) == 0 {
+		return known
+	}
+	return result
+}
+
 // Common container state building functions
 
 // buildCommandState converts API command result into Terraform state.
@@ -272,6 +293,10 @@ func buildMountsUpdateInput(ctx context.Context, currentMounts, previousMounts t
 	if !previousMounts.IsNull() && !previousMounts.IsUnknown() {
 		_ = previousMounts.ElementsAs(ctx, &prevMounts, false)
 	}
+	prevByKey := map[string]*mountResource{}
+	for i := range prevMounts {
+		prevByKey[mountKey(prevMounts[i].Path.ValueString(), prevMounts[i].Volume.ValueString())] = &prevMounts[i]
+	}
 
 	// Build planned mounts
 	plannedMounts := map[string]struct{}{}
@@ -283,24 +308,19 @@ func buildMountsUpdateInput(ctx context.Context, currentMounts, previousMounts t
 		}
 
 		for _, m := range mounts {
-			key := fmt.Sprintf("%s|%s", m.Path.ValueString(), m.Volume.ValueString())
+			key := mountKey(m.Path.ValueString(), m.Volume.ValueString())
 			plannedMounts[key] = struct{}{}
 			mountInputs = append(mountInputs, api.MountInput{
-				Path: m.Path.ValueString(),
-				Volume: api.MountVolumeInput{
-					Name:       m.Volume.ValueString(),
-					AutoCreate: false,
-					Increase:   false,
-					Size:       nil,
-				},
-				State: api.StatePresent,
+				Path:   m.Path.ValueString(),
+				Volume: buildMountVolumeInput(m, prevByKey[key]),
+				State:  api.StatePresent,
 			})
 		}
 	}
 
 	// Mark removed mounts as absent
 	for _, m := range prevMounts {
-		key := fmt.Sprintf("%s|%s", m.Path.ValueString(), m.Volume.ValueString())
+		key := mountKey(m.Path.ValueString(), m.Volume.ValueString())
 		if _, exists := plannedMounts[key]; !exists {
 			mountInputs = append(mountInputs, api.MountInput{
 				Path: m.Path.ValueString(),
@@ -338,7 +358,6 @@ func buildIngressesUpdateInput(ctx context.Context, currentIngresses, previousIn
 		_ = previousIngresses.ElementsAs(ctx, &prevIngresses, false)
 	}
 
-	plannedIngresses := map[string]struct{}{}
 	for _, ing := range ingresses {
 		allowList := []string{}
 		if !ing.AllowList.IsNull() && !ing.AllowList.IsUnknown() {
@@ -351,8 +370,6 @@ func buildIngressesUpdateInput(ctx context.Context, currentIngresses, previousIn
 			allowList = allowListVals
 		}
 
-		key := ing.DomainName.ValueString()
-		plannedIngresses[key] = struct{}{}
 		ingressInputs = append(ingressInputs, api.IngressInput{
 			DomainName: ing.DomainName.ValueStringPointer(),
 			Port:       int(ing.Port.ValueInt64()),
@@ -364,8 +381,7 @@ func buildIngressesUpdateInput(ctx context.Context, currentIngresses, previousIn
 
 	// Mark removed ingresses as absent
 	for _, prevIng := range prevIngresses {
-		key := prevIng.DomainName.ValueString()
-		if _, exists := plannedIngresses[key]; !exists {
+		if !ingressDomainPlanned(ingresses, prevIng.DomainName.ValueString()) {
 			ingressInputs = append(ingressInputs, api.IngressInput{
 				DomainName: prevIng.DomainName.ValueStringPointer(),
 				Port:       int(prevIng.Port.ValueInt64()),
@@ -378,6 +394,17 @@ func buildIngressesUpdateInput(ctx context.Context, currentIngresses, previousIn
 	return ingressInputs, diags
 }
 
+// ingressDomainPlanned reports whether one of the planned ingresses has the
+// given domain, ignoring differences the API normalizes away.
+func ingressDomainPlanned(planned []ingresResource, domain string) bool {
+	for _, ing := range planned {
+		if sameDomainName(ing.DomainName.ValueString(), domain) {
+			return true
+		}
+	}
+	return false
+}
+
 // Common import state builder
 func buildContainerImportState(ctx context.Context, container api.ContainerResult, namespace, name string) (map[string]attr.Value, diag.Diagnostics) {
 	var diags diag.Diagnostics
@@ -404,7 +431,7 @@ func buildContainerImportState(ctx context.Context, container api.ContainerResul
 	// Mounts
 	mountTF := types.ListNull(MountsObjectType())
 	if container.Mounts != nil {
-		mountList, d := buildMountsFromApi(container.Mounts)
+		mountList, d := buildMountsFromApi(ctx, container.Mounts, types.ListNull(MountsObjectType()))
 		diags.Append(d...)
 		if diags.HasError() {
 			return nil, diags
@@ -478,6 +505,63 @@ func parseContainerImportID(importID string) (namespace, name string, err error)
 	return parts[0], parts[1], nil
 }
 
+// containerIdentity is the resource identity of containers, starter
+// containers and container jobs.
+type containerIdentity struct {
+	Name      types.String `tfsdk:"name"`
+	Namespace types.String `tfsdk:"namespace"`
+}
+
+// containerImportTarget returns the namespace and name of the container to
+// import, taken from the import ID or, for import blocks that use an
+// identity, from the identity attributes.
+func containerImportTarget(ctx context.Context, req resource.ImportStateRequest) (namespace, name string, diags diag.Diagnostics) {
+	if req.ID != "" {
+		namespace, name, err := parseContainerImportID(req.ID)
+		if err != nil {
+			diags.AddError("Invalid import ID", err.Error())
+		}
+		return namespace, name, diags
+	}
+	return importIdentityTarget(ctx, req.Identity, "<namespace>/<container_name>")
+}
+
+// containerJobImportTarget is containerImportTarget for container jobs.
+func containerJobImportTarget(ctx context.Context, req resource.ImportStateRequest) (namespace, name string, diags diag.Diagnostics) {
+	if req.ID != "" {
+		parts := strings.SplitN(req.ID, "/", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			diags.AddError(
+				"Invalid import ID",
+				"Expected import ID in the format \"<namespace>/<container_job_name>\", got: "+req.ID,
+			)
+			return "", "", diags
+		}
+		return parts[0], parts[1], diags
+	}
+	return importIdentityTarget(ctx, req.Identity, "<namespace>/<container_job_name>")
+}
+
+// importIdentityTarget returns the namespace and name from the identity of an
+// import block. idFormat names the import ID format in the error shown when
+// neither an ID nor an identity is given.
+func importIdentityTarget(ctx context.Context, identity *tfsdk.ResourceIdentity, idFormat string) (namespace, name string, diags diag.Diagnostics) {
+	if identity == nil || identity.Raw.IsNull() {
+		diags.AddError("Invalid import", "Either an import ID in the format \""+idFormat+"\" or the name and namespace identity attributes are required.")
+		return "", "", diags
+	}
+
+	var target containerIdentity
+	diags.Append(identity.Get(ctx, &target)...)
+	if diags.HasError() {
+		return "", "", diags
+	}
+	if target.Namespace.ValueString() == "" || target.Name.ValueString() == "" {
+		diags.AddError("Invalid import identity", "Both name and namespace must be set in the import identity.")
+	}
+	return target.Namespace.ValueString(), target.Name.ValueString(), diags
+}
+
 // validateScalingConfig validates that only the appropriate scaling input is set based on the type
 func validateScalingConfig(scaling scalingResource) error {
 	scalingType := scaling.Type.ValueString()
@@ -502,3 +586,231 @@ func validateScalingConfig(scaling scalingResource) error {
 
 	return nil
 }
+
+func ScalingTriggerObjectAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"type":      types.StringType,
+		"threshold": types.Int64Type,
+	}
+}
+
+func AutoScalingObjectAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"minimal_replicas": types.Int64Type,
+		"maximal_replicas": types.Int64Type,
+		"triggers":         types.ListType{ElemType: types.ObjectType{AttrTypes: ScalingTriggerObjectAttributeTypes()}},
+	}
+}
+
+func ScalingObjectAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"type":         types.StringType,
+		"manual_input": types.Int64Type,
+		"auto_input":   types.ObjectType{AttrTypes: AutoScalingObjectAttributeTypes()},
+	}
+}
+
+// manualScalingValue returns a manual scaling block with the given replicas.
+func manualScalingValue(replicas int64) types.Object {
+	return types.ObjectValueMust(ScalingObjectAttributeTypes(), map[string]attr.Value{
+		"type":         types.StringValue("manual"),
+		"manual_input": types.Int64Value(replicas),
+		"auto_input":   types.ObjectNull(AutoScalingObjectAttributeTypes()),
+	})
+}
+
+// buildScalingFromApi converts the scaling of an API container into the
+// scaling block. Containers without auto scaling are manually scaled.
+func buildScalingFromApi(container api.ContainerResult) (types.Object, diag.Diagnostics) {
+	if container.AutoScaling == nil {
+		if container.NumberOfReplicas < 0 {
+			return types.ObjectNull(ScalingObjectAttributeTypes()), nil
+		}
+		return manualScalingValue(int64(container.NumberOfReplicas)), nil
+	}
+
+	triggerVals := make([]attr.Value, 0, len(container.AutoScaling.Triggers))
+	for _, t := range container.AutoScaling.Triggers {
+		triggerVals = append(triggerVals, types.ObjectValueMust(ScalingTriggerObjectAttributeTypes(), map[string]attr.Value{
+			"type":      types.StringValue(strings.ToUpper(t.Type)),
+			"threshold": types.Int64Value(int64(t.Threshold)),
+		}))
+	}
+	triggers, diags := types.ListValue(types.ObjectType{AttrTypes: ScalingTriggerObjectAttributeTypes()}, triggerVals)
+	if diags.HasError() {
+		return types.ObjectNull(ScalingObjectAttributeTypes()), diags
+	}
+
+	autoInput := types.ObjectValueMust(AutoScalingObjectAttributeTypes(), map[string]attr.Value{
+		"minimal_replicas": types.Int64Value(int64(container.AutoScaling.Replicas.Minimum)),
+		"maximal_replicas": types.Int64Value(int64(container.AutoScaling.Replicas.Maximum)),
+		"triggers":         triggers,
+	})
+	return types.ObjectValueMust(ScalingObjectAttributeTypes(), map[string]attr.Value{
+		"type":         types.StringValue("auto"),
+		"manual_input": types.Int64Null(),
+		"auto_input":   autoInput,
+	}), diags
+}
+
+// buildScalingInput converts the scaling block into the API input. It returns
+// nil when the input belonging to the scaling type is unknown.
+func buildScalingInput(ctx context.Context, scalingObj types.Object) (*api.ScalingInput, diag.Diagnostics) {
+	var scaling scalingResource
+	diags := scalingObj.As(ctx, &scaling, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	if err := validateScalingConfig(scaling); err != nil {
+		diags.AddError("Invalid scaling configuration", err.Error())
+		return nil, diags
+	}
+
+	switch scaling.Type.ValueString() {
+	case "auto":
+		if scaling.AutoInput.IsNull() || scaling.AutoInput.IsUnknown() {
+			return nil, diags
+		}
+		var autoInput autoscaleResource
+		diags.Append(scaling.AutoInput.As(ctx, &autoInput, basetypes.ObjectAsOptions{})...)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		var triggers []triggerResource
+		diags.Append(autoInput.Triggers.ElementsAs(ctx, &triggers, false)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		auto := api.AutoScalingInput{
+			Replicas: api.ReplicasInput{
+				Minimum: int(autoInput.MinimalReplicas.ValueInt64()),
+				Maximum: int(autoInput.MaximalReplicas.ValueInt64()),
+			},
+		}
+		for _, t := range triggers {
+			auto.Triggers = append(auto.Triggers, api.AutoScalingTriggerInput{
+				Type:      api.AutoScalingType(t.Type.ValueString()),
+				Threshold: int(t.Threshold.ValueInt64()),
+			})
+		}
+		return &api.ScalingInput{Auto: &auto}, diags
+
+	case "manual":
+		if scaling.Manualinput.IsNull() || scaling.Manualinput.IsUnknown() {
+			return nil, diags
+		}
+		return &api.ScalingInput{
+			Manual: &api.ManualScalingInput{
+				Replicas: int(scaling.Manualinput.ValueInt64()),
+			},
+		}, diags
+	}
+
+	return nil, diags
+}
+
+// validateScalingPlan checks the planned scaling block so invalid combinations
+// are reported during plan instead of apply. Checks that depend on values
+// that are still unknown are left to Create and Update.
+func validateScalingPlan(ctx context.Context, scalingObj types.Object) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if scalingObj.IsNull() || scalingObj.IsUnknown() {
+		return diags
+	}
+
+	var scaling scalingResource
+	diags.Append(scalingObj.As(ctx, &scaling, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() || scaling.Type.IsUnknown() || scaling.Manualinput.IsUnknown() || scaling.AutoInput.IsUnknown() {
+		return diags
+	}
+
+	if err := validateScalingConfig(scaling); err != nil {
+		diags.AddAttributeError(path.Root("scaling"), "Invalid scaling configuration", err.Error())
+		return diags
+	}
+
+	if scaling.AutoInput.IsNull() {
+		return diags
+	}
+	var autoInput autoscaleResource
+	diags.Append(scaling.AutoInput.As(ctx, &autoInput, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return diags
+	}
+	if err := validateAutoScalingReplicas(autoInput); err != nil {
+		diags.AddAttributeError(
+			path.Root("scaling").AtName("auto_input").AtName("minimal_replicas"),
+			"Invalid scaling configuration",
+			err.Error(),
+		)
+	}
+	return diags
+}
+
+// validateIngressPortsPlan checks that every planned ingress port is one of
+// the ports in the ports attribute, on either side of the mapping. Malformed
+// ports are reported by their validator, unknown values are left to the API.
+func validateIngressPortsPlan(ctx context.Context, ports types.List, ingresses types.List) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if ports.IsNull() || ports.IsUnknown() || ingresses.IsNull() || ingresses.IsUnknown() {
+		return diags
+	}
+
+	exposed := make(map[int64]bool)
+	for _, elem := range ports.Elements() {
+		port, ok := elem.(types.String)
+		if !ok || port.IsUnknown() {
+			return diags
+		}
+		from, to, err := parsePortMapping(port.ValueString())
+		if err != nil {
+			continue
+		}
+		exposed[int64(from)] = true
+		exposed[int64(to)] = true
+	}
+
+	var ingressData []ingresResource
+	diags.Append(ingresses.ElementsAs(ctx, &ingressData, false)...)
+	if diags.HasError() {
+		return diags
+	}
+	for i, ing := range ingressData {
+		if ing.Port.IsNull() || ing.Port.IsUnknown() || exposed[ing.Port.ValueInt64()] {
+			continue
+		}
+		diags.AddAttributeError(
+			path.Root("ingresses").AtListIndex(i).AtName("port"),
+			"Invalid ingress port",
+			fmt.Sprintf("Port %d is not exposed. Add it to the ports attribute, for example \"%d:%d\".", ing.Port.ValueInt64(), ing.Port.ValueInt64(), ing.Port.ValueInt64()),
+		)
+	}
+	return diags
+}
+
+// scalingChangesReplicas reports whether an update can change the number of
+// replicas, so replicas cannot keep its value from state: the scaling changes,
+// or the container autoscales and the count can move at any time.
+func scalingChangesReplicas(planScaling, stateScaling types.Object) bool {
+	if !planScaling.Equal(stateScaling) {
+		return true
+	}
+	scalingType, ok := planScaling.Attributes()["type"].(types.String)
+	return ok && scalingType.ValueString() == "auto"
+}
+
+// validateAutoScalingReplicas validates that the minimal replicas do not exceed the maximal replicas
+func validateAutoScalingReplicas(autoInput autoscaleResource) error {
+	if autoInput.MinimalReplicas.IsNull() || autoInput.MinimalReplicas.IsUnknown() ||
+		autoInput.MaximalReplicas.IsNull() || autoInput.MaximalReplicas.IsUnknown() {
+		return nil
+	}
+	if autoInput.MinimalReplicas.ValueInt64() > autoInput.MaximalReplicas.ValueInt64() {
+		return fmt.Errorf("minimal_replicas (%d) must not be greater than maximal_replicas (%d)",
+			autoInput.MinimalReplicas.ValueInt64(), autoInput.MaximalReplicas.ValueInt64())
+	}
+	return nil
+}
@@ -8,9 +8,14 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/nexaa-cloud/nexaa-cli/api"
+	nexaaclient "github.com/nexaa-cloud/terraform-provider-nexaa/internal/client"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // --- parseContainerImportID ---
@@ -38,14 +43,154 @@ func Test_ParseContainerImportID_empty_name_errors(t *testing.T) {
 	assert.Error(t, err)
 }
 
+// --- containerImportTarget ---
+
+func containerImportIdentity(t *testing.T, identity *containerIdentity) *tfsdk.ResourceIdentity {
+	t.Helper()
+	ctx := context.Background()
+	var schemaResp resource.IdentitySchemaResponse
+	(&containerResource{}).IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &schemaResp)
+
+	result := &tfsdk.ResourceIdentity{
+		Schema: schemaResp.IdentitySchema,
+		Raw:    tftypes.NewValue(schemaResp.IdentitySchema.Type().TerraformType(ctx), nil),
+	}
+	if identity != nil {
+		diags := result.Set(ctx, identity)
+		require.False(t, diags.HasError(), "%v", diags)
+	}
+	return result
+}
+
+func Test_ContainerImportTarget_from_id(t *testing.T) {
+	ns, name, diags := containerImportTarget(context.Background(), resource.ImportStateRequest{ID: "my-namespace/my-container"})
+	assert.False(t, diags.HasError())
+	assert.Equal(t, "my-namespace", ns)
+	assert.Equal(t, "my-container", name)
+}
+
+func Test_ContainerImportTarget_from_identity(t *testing.T) {
+	req := resource.ImportStateRequest{
+		Identity: containerImportIdentity(t, &containerIdentity{
+			Name:      types.StringValue("my-container"),
+			Namespace: types.StringValue("my-namespace"),
+		}),
+	}
+
+	ns, name, diags := containerImportTarget(context.Background(), req)
+
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "my-namespace", ns)
+	assert.Equal(t, "my-container", name)
+}
+
+func Test_ContainerImportTarget_without_id_or_identity_errors(t *testing.T) {
+	req := resource.ImportStateRequest{Identity: containerImportIdentity(t, nil)}
+	_, _, diags := containerImportTarget(context.Background(), req)
+	assert.True(t, diags.HasError())
+}
+
+func Test_ContainerImportTarget_incomplete_identity_errors(t *testing.T) {
+	req := resource.ImportStateRequest{
+		Identity: containerImportIdentity(t, &containerIdentity{
+			Name:      types.StringValue("my-container"),
+			Namespace: types.StringNull(),
+		}),
+	}
+	_, _, diags := containerImportTarget(context.Background(), req)
+	assert.True(t, diags.HasError())
+}
+
+func Test_ContainerJobImportTarget_from_id(t *testing.T) {
+	ns, name, diags := containerJobImportTarget(context.Background(), resource.ImportStateRequest{ID: "my-namespace/my-job"})
+	assert.False(t, diags.HasError())
+	assert.Equal(t, "my-namespace", ns)
+	assert.Equal(t, "my-job", name)
+}
+
+func Test_ContainerJobImportTarget_invalid_id_errors(t *testing.T) {
+	_, _, diags := containerJobImportTarget(context.Background(), resource.ImportStateRequest{ID: "my-job"})
+	assert.True(t, diags.HasError())
+}
+
+func Test_ContainerJobImportTarget_from_identity(t *testing.T) {
+	ctx := context.Background()
+	var schemaResp resource.IdentitySchemaResponse
+	(&containerJobResource{}).IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &schemaResp)
+	identity := &tfsdk.ResourceIdentity{
+		Schema: schemaResp.IdentitySchema,
+		Raw:    tftypes.NewValue(schemaResp.IdentitySchema.Type().TerraformType(ctx), nil),
+	}
+	diags := identity.Set(ctx, containerIdentity{
+		Name:      types.StringValue("my-job"),
+		Namespace: types.StringValue("my-namespace"),
+	})
+	require.False(t, diags.HasError(), "%v", diags)
+
+	ns, name, diags := containerJobImportTarget(ctx, resource.ImportStateRequest{Identity: identity})
+
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "my-namespace", ns)
+	assert.Equal(t, "my-job", name)
+}
+
+func Test_ContainerJobImportState_registry(t *testing.T) {
+	tests := map[string]struct {
+		registry *api.ContainerJobResultPrivateRegistry
+		want     types.String
+	}{
+		"no registry":      {registry: nil, want: types.StringNull()},
+		"public registry":  {registry: &api.ContainerJobResultPrivateRegistry{Name: "public"}, want: types.StringNull()},
+		"private registry": {registry: &api.ContainerJobResultPrivateRegistry{Name: "my-registry"}, want: types.StringValue("my-registry")},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			m := new(nexaaclient.MockNexaaAPI)
+			m.On("ContainerJobByName", "my-namespace", "my-job").Return(api.ContainerJobResult{
+				Name:            "my-job",
+				Image:           "alpine:latest",
+				Resources:       api.ContainerResourcesCpu250Ram500,
+				PrivateRegistry: tc.registry,
+			}, nil)
+			r := &containerJobResource{nexaaClient: nexaaclient.NewWithAPI(m)}
+
+			var schemaResp resource.SchemaResponse
+			r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+			var identitySchemaResp resource.IdentitySchemaResponse
+			r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &identitySchemaResp)
+			resp := &resource.ImportStateResponse{
+				State: tfsdk.State{
+					Schema: schemaResp.Schema,
+					Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+				},
+				Identity: &tfsdk.ResourceIdentity{
+					Schema: identitySchemaResp.IdentitySchema,
+					Raw:    tftypes.NewValue(identitySchemaResp.IdentitySchema.Type().TerraformType(ctx), nil),
+				},
+			}
+
+			r.ImportState(ctx, resource.ImportStateRequest{ID: "my-namespace/my-job"}, resp)
+
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+			var state containerJobResource
+			require.False(t, resp.State.Get(ctx, &state).HasError())
+			assert.Equal(t, tc.want, state.Registry)
+		})
+	}
+}
+
 // --- buildMountsUpdateInput ---
 
 func makeMountList(mounts ...map[string]string) types.List {
 	elems := make([]attr.Value, len(mounts))
 	for i, m := range mounts {
 		elems[i] = types.ObjectValueMust(MountsObjectAttributeTypes(), map[string]attr.Value{
-			"path":   types.StringValue(m["path"]),
-			"volume": types.StringValue(m["volume"]),
+			"path":        types.StringValue(m["path"]),
+			"volume":      types.StringValue(m["volume"]),
+			"auto_create": types.BoolValue(false),
+			"size":        types.Int64Null(),
 		})
 	}
 	return types.ListValueMust(MountsObjectType(), elems)
@@ -102,3 +247,74 @@ func Test_BuildMountsUpdateInput_add_and_remove(t *testing.T) {
 	assert.Equal(t, api.StatePresent, byPath["/new"].State)
 	assert.Equal(t, api.StateAbsent, byPath["/old"].State)
 }
+
+func Test_BuildMountsUpdateInput_larger_size_increases_volume(t *testing.T) {
+	mountWithSize := func(size int64) types.List {
+		return types.ListValueMust(MountsObjectType(), []attr.Value{
+			types.ObjectValueMust(MountsObjectAttributeTypes(), map[string]attr.Value{
+				"path":        types.StringValue("/data"),
+				"volume":      types.StringValue("vol-a"),
+				"auto_create": types.BoolValue(true),
+				"size":        types.Int64Value(size),
+			}),
+		})
+	}
+	result, diags := buildMountsUpdateInput(context.Background(), mountWithSize(10), mountWithSize(5))
+	assert.False(t, diags.HasError())
+	assert.Len(t, result, 1)
+	assert.True(t, result[0].Volume.Increase)
+	assert.Equal(t, 10, *result[0].Volume.Size)
+}
+
+// --- validateIngressPortsPlan ---
+
+func portsList(ports ...string) types.List {
+	elems := make([]attr.Value, len(ports))
+	for i, p := range ports {
+		elems[i] = types.StringValue(p)
+	}
+	return types.ListValueMust(types.StringType, elems)
+}
+
+func Test_ValidateIngressPortsPlan_exposed_port_valid(t *testing.T) {
+	diags := validateIngressPortsPlan(context.Background(), portsList("8080:80"), makeKnownIngressList("app.example.com"))
+	assert.False(t, diags.HasError())
+}
+
+func Test_ValidateIngressPortsPlan_missing_port_errors(t *testing.T) {
+	diags := validateIngressPortsPlan(context.Background(), portsList("443:443"), makeKnownIngressList("app.example.com"))
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags.Errors()[0].Detail(), "Port 80 is not exposed")
+}
+
+func Test_ValidateIngressPortsPlan_unknown_ports_skipped(t *testing.T) {
+	diags := validateIngressPortsPlan(context.Background(), types.ListUnknown(types.StringType), makeKnownIngressList("app.example.com"))
+	assert.False(t, diags.HasError())
+
+	ports := types.ListValueMust(types.StringType, []attr.Value{types.StringUnknown()})
+	diags = validateIngressPortsPlan(context.Background(), ports, makeKnownIngressList("app.example.com"))
+	assert.False(t, diags.HasError())
+}
+
+func Test_BuildCommandResetInput_null_resets(t *testing.T) {
+	result, diags := buildCommandResetInput(context.Background(), types.ListNull(types.StringType))
+	assert.False(t, diags.HasError())
+	assert.Nil(t, result)
+}
+
+func Test_BuildCommandResetInput_empty_is_sent(t *testing.T) {
+	result, diags := buildCommandResetInput(context.Background(), types.ListValueMust(types.StringType, []attr.Value{}))
+	assert.False(t, diags.HasError())
+	assert.NotNil(t, result)
+	assert.Empty(t, result)
+}
+
+func Test_KeepEmptyList(t *testing.T) {
+	empty := types.ListValueMust(types.StringType, []attr.Value{})
+	null := types.ListNull(types.StringType)
+	command := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("serve")})
+
+	assert.True(t, keepEmptyList(null, empty).Equal(empty))
+	assert.True(t, keepEmptyList(null, null).IsNull())
+	assert.True(t, keepEmptyList(command, empty).Equal(command))
+}
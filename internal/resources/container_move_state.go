@@ -0,0 +1,95 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/nexaa-cloud/nexaa-cli/api"
+)
+
+// starterContainerReplicas is the number of replicas a starter container runs.
+const starterContainerReplicas = 1
+
+// MoveState lets a moved block turn a nexaa_starter_container into a
+// nexaa_container without destroying and recreating the container.
+func (r *containerResource) MoveState(ctx context.Context) []resource.StateMover {
+	var starterSchema resource.SchemaResponse
+	(&starterContainerResource{}).Schema(ctx, resource.SchemaRequest{}, &starterSchema)
+
+	return []resource.StateMover{
+		{
+			SourceSchema: &starterSchema.Schema,
+			StateMover:   moveStarterContainerState,
+		},
+	}
+}
+
+func moveStarterContainerState(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+	if req.SourceTypeName != "nexaa_starter_container" || req.SourceState == nil {
+		return
+	}
+
+	var source starterContainerResource
+	resp.Diagnostics.Append(req.SourceState.Get(ctx, &source)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	target, diags := containerFromStarterContainer(source)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.TargetState.Set(ctx, &target)...)
+	// The write-only names and secret hashes apply to the same container.
+	resp.TargetPrivate = req.SourcePrivate
+	if resp.TargetIdentity != nil {
+		resp.Diagnostics.Append(resp.TargetIdentity.Set(ctx, containerIdentity{
+			Name:      target.Name,
+			Namespace: target.Namespace,
+		})...)
+	}
+}
+
+// containerFromStarterContainer maps the state of a starter container onto a
+// container. The attributes starter containers lack get the fixed values the
+// API uses for them: the smallest resources and a single replica.
+func containerFromStarterContainer(source starterContainerResource) (containerResource, diag.Diagnostics) {
+	resources, diags := buildContainerResourcesFromApi(api.ContainerResourcesCpu250Ram500)
+	if diags.HasError() {
+		return containerResource{}, diags
+	}
+
+	return containerResource{
+		ID:                   source.ID,
+		Name:                 source.Name,
+		Namespace:            source.Namespace,
+		Image:                source.Image,
+		Registry:             source.Registry,
+		Resources:            resources,
+		Command:              source.Command,
+		Entrypoint:           source.Entrypoint,
+		EnvironmentVariables: source.EnvironmentVariables,
+		SecretEnvWO:          types.MapNull(types.StringType),
+		SecretEnvWOVersion:   source.SecretEnvWOVersion,
+		EnvFrom:              source.EnvFrom,
+		Ports:                source.Ports,
+		Ingresses:            source.Ingresses,
+		ExternalConnection:   source.ExternalConnection,
+		Mounts:               source.Mounts,
+		HealthCheck:          source.HealthCheck,
+		Scaling:              manualScalingValue(starterContainerReplicas),
+		Replicas:             types.Int64Value(starterContainerReplicas),
+		Status:               source.Status,
+		WaitForReady:         source.WaitForReady,
+		RedeployTrigger:      source.RedeployTrigger,
+		RollbackOnFailure:    types.BoolValue(false),
+		Timeouts:             source.Timeouts,
+	}, diags
+}
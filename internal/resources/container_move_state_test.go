@@ -0,0 +1,71 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runMoveStarterContainerState(t *testing.T, sourceTypeName string) resource.MoveStateResponse {
+	t.Helper()
+	ctx := context.Background()
+	var targetSchema resource.SchemaResponse
+	(&containerResource{}).Schema(ctx, resource.SchemaRequest{}, &targetSchema)
+
+	source := buildStarterContainerState(t, "test-ns", "my-starter")
+	resp := resource.MoveStateResponse{
+		TargetState: tfsdk.State{
+			Schema: targetSchema.Schema,
+			Raw:    tftypes.NewValue(targetSchema.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+	moveStarterContainerState(ctx, resource.MoveStateRequest{
+		SourceTypeName: sourceTypeName,
+		SourceState:    &source,
+	}, &resp)
+	return resp
+}
+
+func Test_MoveStarterContainerState_maps_shared_attributes(t *testing.T) {
+	resp := runMoveStarterContainerState(t, "nexaa_starter_container")
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	var target containerResource
+	diags := resp.TargetState.Get(context.Background(), &target)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "my-starter", target.Name.ValueString())
+	assert.Equal(t, "test-ns", target.Namespace.ValueString())
+	assert.Equal(t, "nginx:latest", target.Image.ValueString())
+	assert.True(t, target.Resources.Equal(containerResourcesValue(0.25, 0.5)))
+	assert.True(t, target.Scaling.Equal(buildContainerScalingObj()))
+	assert.False(t, target.WaitForReady.ValueBool())
+}
+
+func Test_ContainerFromStarterContainer_keeps_environment_settings(t *testing.T) {
+	target, diags := containerFromStarterContainer(starterContainerResource{
+		SecretEnvWO:        types.MapNull(types.StringType),
+		SecretEnvWOVersion: types.Int64Value(2),
+		EnvFrom:            types.ListNull(envFromObjectType()),
+		RedeployTrigger:    types.MapNull(types.StringType),
+	})
+
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.True(t, target.SecretEnvWO.IsNull())
+	assert.Equal(t, types.Int64Value(2), target.SecretEnvWOVersion)
+	assert.True(t, target.EnvFrom.Equal(types.ListNull(envFromObjectType())))
+}
+
+func Test_MoveStarterContainerState_ignores_other_resource_types(t *testing.T) {
+	resp := runMoveStarterContainerState(t, "nexaa_container_job")
+	assert.False(t, resp.Diagnostics.HasError())
+	assert.True(t, resp.TargetState.Raw.IsNull())
+}
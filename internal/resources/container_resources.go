@@ -0,0 +1,122 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/nexaa-cloud/nexaa-cli/api"
+)
+
+type containerResourcesResource struct {
+	CPU types.Float64 `tfsdk:"cpu"`
+	RAM types.Float64 `tfsdk:"ram"`
+}
+
+func ContainerResourcesObjectAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"cpu": types.Float64Type,
+		"ram": types.Float64Type,
+	}
+}
+
+// containerResourcesName returns the API name of a cpu/ram combination, e.g. CPU_250_RAM_500.
+func containerResourcesName(cpu, ram float64) api.ContainerResources {
+	return api.ContainerResources(fmt.Sprintf("CPU_%d_RAM_%d", int(math.Round(cpu*1000)), int(math.Round(ram*1000))))
+}
+
+// isValidContainerResources reports whether the API offers the given combination.
+func isValidContainerResources(resources api.ContainerResources) bool {
+	for _, r := range api.AllContainerResources {
+		if r == resources {
+			return true
+		}
+	}
+	return false
+}
+
+// ramOfferedWithCPU returns the amounts of ram in GB the API offers with the
+// given cpu, in the order the API client lists them.
+func ramOfferedWithCPU(cpu float64) []float64 {
+	var ram []float64
+	for _, r := range api.AllContainerResources {
+		c, m, err := parseContainerResources(r)
+		if err == nil && math.Round(c*1000) == math.Round(cpu*1000) {
+			ram = append(ram, m)
+		}
+	}
+	return ram
+}
+
+// parseContainerResources splits an API resources name into cpu and ram.
+func parseContainerResources(resources api.ContainerResources) (float64, float64, error) {
+	var cpu, ram int
+	if _, err := fmt.Sscanf(string(resources), "CPU_%d_RAM_%d", &cpu, &ram); err != nil {
+		return 0, 0, fmt.Errorf("unexpected container resources %q", resources)
+	}
+	return float64(cpu) / 1000, float64(ram) / 1000, nil
+}
+
+func buildContainerResourcesFromApi(resources api.ContainerResources) (types.Object, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	cpu, ram, err := parseContainerResources(resources)
+	if err != nil {
+		diags.AddError("Invalid container resources", err.Error())
+		return types.ObjectNull(ContainerResourcesObjectAttributeTypes()), diags
+	}
+	return types.ObjectValueMust(ContainerResourcesObjectAttributeTypes(), map[string]attr.Value{
+		"cpu": types.Float64Value(cpu),
+		"ram": types.Float64Value(ram),
+	}), diags
+}
+
+func buildContainerResourcesInput(ctx context.Context, resources types.Object) (api.ContainerResources, diag.Diagnostics) {
+	var r containerResourcesResource
+	diags := resources.As(ctx, &r, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return "", diags
+	}
+	return containerResourcesName(r.CPU.ValueFloat64(), r.RAM.ValueFloat64()), diags
+}
+
+// upgradeContainerStateV0 converts the resources string of schema version 0
+// into the cpu/ram object. The raw JSON state is rewritten so the rest of the
+// state does not need a copy of the version 0 schema.
+func upgradeContainerStateV0(_ context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	if req.RawState == nil || req.RawState.JSON == nil {
+		resp.Diagnostics.AddError("Unable to upgrade state", "The prior container state is missing.")
+		return
+	}
+
+	var state map[string]any
+	if err := json.Unmarshal(req.RawState.JSON, &state); err != nil {
+		resp.Diagnostics.AddError("Unable to upgrade state", "Could not read the prior container state: "+err.Error())
+		return
+	}
+
+	if name, ok := state["resources"].(string); ok {
+		cpu, ram, err := parseContainerResources(api.ContainerResources(name))
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to upgrade state", err.Error())
+			return
+		}
+		state["resources"] = map[string]any{"cpu": cpu, "ram": ram}
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to upgrade state", "Could not write the upgraded container state: "+err.Error())
+		return
+	}
+	resp.DynamicValue = &tfprotov6.DynamicValue{JSON: data}
+}
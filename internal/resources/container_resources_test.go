@@ -0,0 +1,134 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/nexaa-cloud/nexaa-cli/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func containerResourcesValue(cpu, ram float64) types.Object {
+	return types.ObjectValueMust(ContainerResourcesObjectAttributeTypes(), map[string]attr.Value{
+		"cpu": types.Float64Value(cpu),
+		"ram": types.Float64Value(ram),
+	})
+}
+
+func Test_ContainerResourcesName(t *testing.T) {
+	assert.Equal(t, api.ContainerResources("CPU_250_RAM_500"), containerResourcesName(0.25, 0.5))
+	assert.Equal(t, api.ContainerResources("CPU_1000_RAM_2000"), containerResourcesName(1, 2))
+}
+
+func Test_ParseContainerResources_round_trip(t *testing.T) {
+	for _, r := range api.AllContainerResources {
+		cpu, ram, err := parseContainerResources(r)
+		require.NoError(t, err)
+		assert.Equal(t, r, containerResourcesName(cpu, ram))
+	}
+}
+
+func Test_ParseContainerResources_invalid(t *testing.T) {
+	_, _, err := parseContainerResources("small")
+	assert.Error(t, err)
+}
+
+func Test_BuildContainerResourcesInput(t *testing.T) {
+	name, diags := buildContainerResourcesInput(context.Background(), containerResourcesValue(0.25, 0.5))
+	require.False(t, diags.HasError())
+	assert.Equal(t, api.ContainerResources("CPU_250_RAM_500"), name)
+}
+
+func Test_ContainerResourcesValidator(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   types.Object
+		wantErr bool
+	}{
+		{"valid combination", containerResourcesValue(0.25, 0.5), false},
+		{"invalid combination", containerResourcesValue(0.25, 64), true},
+		{"null", types.ObjectNull(ContainerResourcesObjectAttributeTypes()), false},
+		{"unknown", types.ObjectUnknown(ContainerResourcesObjectAttributeTypes()), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &validator.ObjectResponse{}
+			containerResourcesValidator{}.ValidateObject(context.Background(), validator.ObjectRequest{
+				Path:        path.Root("resources"),
+				ConfigValue: tt.value,
+			}, resp)
+			assert.Equal(t, tt.wantErr, resp.Diagnostics.HasError())
+		})
+	}
+}
+
+func Test_ContainerResourcesValidator_lists_ram_offered_with_cpu(t *testing.T) {
+	resp := &validator.ObjectResponse{}
+	containerResourcesValidator{}.ValidateObject(context.Background(), validator.ObjectRequest{
+		Path:        path.Root("resources"),
+		ConfigValue: containerResourcesValue(0.25, 64),
+	}, resp)
+
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "With a cpu of 0.25 the API offers a ram of 0.5, 1, 2,")
+}
+
+func Test_RamOfferedWithCPU(t *testing.T) {
+	assert.Contains(t, ramOfferedWithCPU(0.75), 2.0)
+	assert.Empty(t, ramOfferedWithCPU(0.3))
+}
+
+func Test_UpgradeContainerStateV0_converts_resources(t *testing.T) {
+	req := resource.UpgradeStateRequest{
+		RawState: &tfprotov6.RawState{JSON: []byte(`{"name":"web","resources":"CPU_500_RAM_1000"}`)},
+	}
+	resp := &resource.UpgradeStateResponse{}
+
+	upgradeContainerStateV0(context.Background(), req, resp)
+
+	require.False(t, resp.Diagnostics.HasError())
+	require.NotNil(t, resp.DynamicValue)
+	var state map[string]any
+	require.NoError(t, json.Unmarshal(resp.DynamicValue.JSON, &state))
+	assert.Equal(t, "web", state["name"])
+	assert.Equal(t, map[string]any{"cpu": 0.5, "ram": 1.0}, state["resources"])
+}
+
+func Test_ContainerJobUpgradeState_converts_resources(t *testing.T) {
+	upgraders := (&containerJobResource{}).UpgradeState(context.Background())
+	require.Contains(t, upgraders, int64(0))
+	req := resource.UpgradeStateRequest{
+		RawState: &tfprotov6.RawState{JSON: []byte(`{"name":"backup","schedule":"0 3 * * *","resources":"CPU_250_RAM_500"}`)},
+	}
+	resp := &resource.UpgradeStateResponse{}
+
+	upgraders[0].StateUpgrader(context.Background(), req, resp)
+
+	require.False(t, resp.Diagnostics.HasError())
+	var state map[string]any
+	require.NoError(t, json.Unmarshal(resp.DynamicValue.JSON, &state))
+	assert.Equal(t, "0 3 * * *", state["schedule"])
+	assert.Equal(t, map[string]any{"cpu": 0.25, "ram": 0.5}, state["resources"])
+}
+
+func Test_UpgradeContainerStateV0_invalid_resources(t *testing.T) {
+	req := resource.UpgradeStateRequest{
+		RawState: &tfprotov6.RawState{JSON: []byte(`{"resources":"small"}`)},
+	}
+	resp := &resource.UpgradeStateResponse{}
+
+	upgradeContainerStateV0(context.Background(), req, resp)
+
+	assert.True(t, resp.Diagnostics.HasError())
+}
@@ -0,0 +1,129 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/nexaa-cloud/nexaa-cli/api"
+	nexaaclient "github.com/nexaa-cloud/terraform-provider-nexaa/internal/client"
+)
+
+// rollbackTimeout bounds the rollback, which runs after the update timeout
+// may already have expired.
+const rollbackTimeout = 5 * time.Minute
+
+// buildContainerRollbackInput returns the modify input that brings a container
+// back from the failed configuration to the previous one. Write-only and
+// env_from variables are left as they are, as their previous values are not
+// known.
+func buildContainerRollbackInput(ctx context.Context, prev containerResource, failed containerResource) (api.ContainerModifyInput, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	resources, d := buildContainerResourcesInput(ctx, prev.Resources)
+	diags.Append(d...)
+	if diags.HasError() {
+		return api.ContainerModifyInput{}, diags
+	}
+
+	input := api.ContainerModifyInput{
+		Namespace: prev.Namespace.ValueString(),
+		Name:      prev.Name.ValueString(),
+		Image:     prev.Image.ValueStringPointer(),
+		Registry:  prev.Registry.ValueStringPointer(),
+		Resources: &resources,
+	}
+
+	command, shouldUpdateCmd, d := buildCommandUpdateInput(ctx, prev.Command)
+	diags.Append(d...)
+	if shouldUpdateCmd {
+		input.Command = command
+	}
+
+	entrypoint, shouldUpdateEp, d := buildEntrypointUpdateInput(ctx, prev.Entrypoint)
+	diags.Append(d...)
+	if shouldUpdateEp {
+		input.Entrypoint = entrypoint
+	}
+
+	input.Ports, d = buildPortsInput(ctx, prev.Ports)
+	diags.Append(d...)
+
+	input.Mounts, d = buildMountsUpdateInput(ctx, prev.Mounts, failed.Mounts)
+	diags.Append(d...)
+
+	input.Ingresses, d = buildIngressesUpdateInput(ctx, prev.Ingresses, failed.Ingresses)
+	diags.Append(d...)
+
+	input.ExternalConnection, d = buildExternalConnectionInputContainer(ctx, prev, &failed)
+	diags.Append(d...)
+
+	env, d := buildEnvRollbackInputs(ctx, prev.EnvironmentVariables, failed.EnvironmentVariables)
+	diags.Append(d...)
+	if len(env) > 0 {
+		input.EnvironmentVariables = env
+	}
+
+	input.HealthCheck, d = buildHealthCheckInput(ctx, prev.HealthCheck)
+	diags.Append(d...)
+
+	input.Scaling, d = buildScalingInput(ctx, prev.Scaling)
+	diags.Append(d...)
+
+	return input, diags
+}
+
+// buildEnvRollbackInputs restores the variables the failed update changed or
+// removed and removes the ones it added. Secrets that are only known masked
+// cannot be restored and are left as they are.
+func buildEnvRollbackInputs(ctx context.Context, prevSet, failedSet types.Set) ([]api.EnvironmentVariableInput, diag.Diagnostics) {
+	prev, diags := extractEnvInputsFromSet(ctx, prevSet)
+	failed, d := extractEnvInputsFromSet(ctx, failedSet)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	failedByName := make(map[string]api.EnvironmentVariableInput, len(failed))
+	for _, f := range failed {
+		failedByName[f.Name] = f
+	}
+
+	var inputs []api.EnvironmentVariableInput
+	prevNames := make(map[string]bool, len(prev))
+	for _, p := range prev {
+		prevNames[p.Name] = true
+		if f, ok := failedByName[p.Name]; ok && f.Value == p.Value && f.Secret == p.Secret {
+			continue
+		}
+		if p.Secret && p.Value == maskedSecretValue {
+			continue
+		}
+		inputs = append(inputs, p)
+	}
+	for _, f := range failed {
+		if !prevNames[f.Name] {
+			inputs = append(inputs, api.EnvironmentVariableInput{Name: f.Name, State: api.StateAbsent})
+		}
+	}
+	return inputs, diags
+}
+
+// rollbackContainer re-applies the previous configuration of a container that
+// did not become ready after an update.
+func rollbackContainer(ctx context.Context, client nexaaclient.NexaaAPI, pollInterval time.Duration, input api.ContainerModifyInput) error {
+	// The update context has usually expired by now, so the rollback gets
+	// its own deadline.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), rollbackTimeout)
+	defer cancel()
+
+	if err := waitForUnlocked(ctx, containerLocked(), client, pollInterval, input.Namespace, input.Name); err != nil {
+		return err
+	}
+	_, err := client.ContainerModify(input)
+	return err
+}
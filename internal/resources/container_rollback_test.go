@@ -0,0 +1,91 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/nexaa-cloud/nexaa-cli/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func envSet(vars ...environmentVariableResource) types.Set {
+	elems := make([]attr.Value, len(vars))
+	for i, v := range vars {
+		elems[i] = types.ObjectValueMust(envVarObjectType().AttrTypes, map[string]attr.Value{
+			"name":   v.Name,
+			"value":  v.Value,
+			"secret": v.Secret,
+		})
+	}
+	return types.SetValueMust(envVarObjectType(), elems)
+}
+
+func envVar(name, value string, secret bool) environmentVariableResource {
+	return environmentVariableResource{
+		Name:   types.StringValue(name),
+		Value:  types.StringValue(value),
+		Secret: types.BoolValue(secret),
+	}
+}
+
+func rollbackTestContainer(image string, env types.Set) containerResource {
+	return containerResource{
+		Namespace:            types.StringValue("ns"),
+		Name:                 types.StringValue("web"),
+		Image:                types.StringValue(image),
+		Registry:             types.StringNull(),
+		Resources:            containerResourcesValue(0.25, 0.5),
+		Command:              types.ListNull(types.StringType),
+		Entrypoint:           types.ListNull(types.StringType),
+		Ports:                types.ListValueMust(types.StringType, []attr.Value{types.StringValue("80:80")}),
+		Mounts:               types.ListNull(MountsObjectType()),
+		Ingresses:            types.ListNull(IngressObjectType()),
+		ExternalConnection:   types.ObjectNull(ExternalConnectionWithPortsObjectAttributeTypes()),
+		EnvironmentVariables: env,
+		HealthCheck:          types.ObjectNull(map[string]attr.Type{"port": types.Int64Type, "path": types.StringType}),
+		Scaling:              buildContainerScalingObj(),
+	}
+}
+
+func Test_BuildContainerRollbackInput_restores_previous_config(t *testing.T) {
+	prev := rollbackTestContainer("nginx:1.27", envSet(envVar("MODE", "production", false)))
+	failed := rollbackTestContainer("nginx:broken", envSet(envVar("MODE", "debug", false)))
+
+	input, diags := buildContainerRollbackInput(context.Background(), prev, failed)
+
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "nginx:1.27", *input.Image)
+	assert.Equal(t, api.ContainerResources("CPU_250_RAM_500"), *input.Resources)
+	assert.Equal(t, []api.EnvironmentVariableInput{
+		{Name: "MODE", Value: "production", State: api.StatePresent},
+	}, input.EnvironmentVariables)
+	assert.NotNil(t, input.Scaling)
+}
+
+func Test_BuildEnvRollbackInputs(t *testing.T) {
+	prev := envSet(
+		envVar("KEEP", "same", false),
+		envVar("CHANGED", "old", false),
+		envVar("TOKEN", maskedSecretValue, true),
+	)
+	failed := envSet(
+		envVar("KEEP", "same", false),
+		envVar("CHANGED", "new", false),
+		envVar("TOKEN", "rotated", true),
+		envVar("ADDED", "x", false),
+	)
+
+	inputs, diags := buildEnvRollbackInputs(context.Background(), prev, failed)
+
+	require.False(t, diags.HasError())
+	assert.ElementsMatch(t, []api.EnvironmentVariableInput{
+		{Name: "CHANGED", Value: "old", State: api.StatePresent},
+		{Name: "ADDED", State: api.StateAbsent},
+	}, inputs)
+}
@@ -4,10 +4,13 @@
 package resources
 
 import (
+	"context"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/nexaa-cloud/nexaa-cli/api"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -73,3 +76,78 @@ func Test_ValidateScalingConfig_auto_missing_auto_input_errors(t *testing.T) {
 	}
 	assert.ErrorContains(t, validateScalingConfig(s), "auto_input is required")
 }
+
+func Test_ValidateAutoScalingReplicas_min_greater_than_max_errors(t *testing.T) {
+	auto := autoscaleResource{
+		MinimalReplicas: types.Int64Value(5),
+		MaximalReplicas: types.Int64Value(2),
+	}
+	assert.ErrorContains(t, validateAutoScalingReplicas(auto), "must not be greater than maximal_replicas")
+}
+
+func Test_ValidateAutoScalingReplicas_unknown_skipped(t *testing.T) {
+	auto := autoscaleResource{
+		MinimalReplicas: types.Int64Unknown(),
+		MaximalReplicas: types.Int64Value(2),
+	}
+	assert.NoError(t, validateAutoScalingReplicas(auto))
+}
+
+func Test_ValidateScalingPlan_manual_valid(t *testing.T) {
+	diags := validateScalingPlan(context.Background(), buildContainerScalingObj())
+	assert.False(t, diags.HasError())
+}
+
+func Test_ValidateScalingPlan_manual_missing_input_errors(t *testing.T) {
+	obj := buildContainerScalingObj()
+	attrs := obj.Attributes()
+	attrs["manual_input"] = types.Int64Null()
+	diags := validateScalingPlan(context.Background(), types.ObjectValueMust(obj.AttributeTypes(context.Background()), attrs))
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags.Errors()[0].Detail(), "manual_input is required")
+}
+
+func Test_ValidateScalingPlan_unknown_manual_input_skipped(t *testing.T) {
+	obj := buildContainerScalingObj()
+	attrs := obj.Attributes()
+	attrs["manual_input"] = types.Int64Unknown()
+	diags := validateScalingPlan(context.Background(), types.ObjectValueMust(obj.AttributeTypes(context.Background()), attrs))
+	assert.False(t, diags.HasError())
+}
+
+func Test_BuildScalingFromApi_manual(t *testing.T) {
+	scaling, diags := buildScalingFromApi(api.ContainerResult{NumberOfReplicas: 3})
+	assert.False(t, diags.HasError())
+
+	var result scalingResource
+	assert.False(t, scaling.As(context.Background(), &result, basetypes.ObjectAsOptions{}).HasError())
+	assert.Equal(t, "manual", result.Type.ValueString())
+	assert.Equal(t, int64(3), result.Manualinput.ValueInt64())
+	assert.True(t, result.AutoInput.IsNull())
+}
+
+func Test_BuildScalingFromApi_negative_replicas_null(t *testing.T) {
+	scaling, diags := buildScalingFromApi(api.ContainerResult{NumberOfReplicas: -1})
+	assert.False(t, diags.HasError())
+	assert.True(t, scaling.IsNull())
+}
+
+func Test_ScalingChangesReplicas_unchanged_manual(t *testing.T) {
+	assert.False(t, scalingChangesReplicas(buildContainerScalingObj(), buildContainerScalingObj()))
+}
+
+func Test_ScalingChangesReplicas_changed_manual_input(t *testing.T) {
+	obj := buildContainerScalingObj()
+	attrs := obj.Attributes()
+	attrs["manual_input"] = types.Int64Value(attrs["manual_input"].(types.Int64).ValueInt64() + 1)
+	changed := types.ObjectValueMust(obj.AttributeTypes(context.Background()), attrs)
+	assert.True(t, scalingChangesReplicas(changed, obj))
+}
+
+func Test_ScalingChangesReplicas_auto(t *testing.T) {
+	obj := buildContainerScalingObj()
+	attrs := obj.Attributes()
+	attrs["type"] = types.StringValue("auto")
+	auto := types.ObjectValueMust(obj.AttributeTypes(context.Background()), attrs)
+	assert.True(t, scalingChangesReplicas(auto, auto))
+}
@@ -16,9 +16,12 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/nexaa-cloud/nexaa-cli/api"
 	nexaaclient "github.com/nexaa-cloud/terraform-provider-nexaa/internal/client"
 	"github.com/stretchr/testify/assert"
@@ -465,8 +468,11 @@ func buildContainerJobPlan(t *testing.T, namespace, name string) tfsdk.Plan {
 		Namespace:            types.StringValue(namespace),
 		Image:                types.StringValue("nginx:latest"),
 		Registry:             types.StringNull(),
-		Resources:            types.StringValue("cpu250-ram500"),
+		Resources:            containerResourcesValue(0.25, 0.5),
 		EnvironmentVariables: types.SetNull(envVarObjectType()),
+		SecretEnvWO:          types.MapNull(types.StringType),
+		SecretEnvWOVersion:   types.Int64Null(),
+		EnvFrom:              types.ListNull(envFromObjectType()),
 		Command:              types.ListNull(types.StringType),
 		Entrypoint:           types.ListNull(types.StringType),
 		Mounts:               types.ListNull(MountsObjectType()),
@@ -492,8 +498,11 @@ func buildContainerJobState(t *testing.T, namespace, name string) tfsdk.State {
 		Namespace:            types.StringValue(namespace),
 		Image:                types.StringValue("nginx:latest"),
 		Registry:             types.StringNull(),
-		Resources:            types.StringValue("cpu250-ram500"),
+		Resources:            containerResourcesValue(0.25, 0.5),
 		EnvironmentVariables: types.SetNull(envVarObjectType()),
+		SecretEnvWO:          types.MapNull(types.StringType),
+		SecretEnvWOVersion:   types.Int64Null(),
+		EnvFrom:              types.ListNull(envFromObjectType()),
 		Command:              types.ListNull(types.StringType),
 		Entrypoint:           types.ListNull(types.StringType),
 		Mounts:               types.ListNull(MountsObjectType()),
@@ -586,8 +595,11 @@ func buildCloudDBClusterPlan(t *testing.T, namespace, name string) tfsdk.Plan {
 		},
 		Plan:               types.StringValue("starter"),
 		Hostname:           types.StringNull(),
+		Port:               types.Int64Null(),
+		PrimaryEndpoint:    types.StringNull(),
 		ExternalConnection: types.ObjectNull(ExternalConnectionObjectAttributeTypes()),
 		State:              types.StringNull(),
+		DeletionProtection: types.BoolValue(false),
 		Timeouts:           cloudDBClusterTimeouts(),
 	})
 	require.False(t, diags.HasError(), fmt.Sprintf("buildCloudDBClusterPlan: %v", diags))
@@ -613,8 +625,11 @@ func buildCloudDBClusterState(t *testing.T, namespace, name string) tfsdk.State
 		},
 		Plan:               types.StringValue("starter"),
 		Hostname:           types.StringValue("db.example.com"),
+		Port:               types.Int64Null(),
+		PrimaryEndpoint:    types.StringNull(),
 		ExternalConnection: types.ObjectNull(ExternalConnectionObjectAttributeTypes()),
 		State:              types.StringValue("active"),
+		DeletionProtection: types.BoolValue(false),
 		Timeouts:           cloudDBClusterTimeouts(),
 	})
 	require.False(t, diags.HasError(), fmt.Sprintf("buildCloudDBClusterState: %v", diags))
@@ -661,6 +676,20 @@ func Test_CloudDatabaseClusterRead_api_error_surfaced(t *testing.T) {
 	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "internal server error")
 }
 
+func Test_CloudDatabaseClusterDelete_deletion_protection_blocks_delete(t *testing.T) {
+	ctx := context.Background()
+	state := buildCloudDBClusterState(t, "test-ns", "my-cluster")
+	require.False(t, state.SetAttribute(ctx, path.Root("deletion_protection"), types.BoolValue(true)).HasError())
+
+	// The mock has no expectations, so any API call fails the test.
+	r := &cloudDatabaseClusterResource{nexaaClient: nexaaclient.NewWithAPI(new(nexaaclient.MockNexaaAPI))}
+	resp := &resource.DeleteResponse{}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Summary(), "protected from deletion")
+}
+
 // ── cloud database cluster database ──────────────────────────────────────────
 
 func cloudDBClusterDatabaseTimeouts() timeouts.Value {
@@ -858,6 +887,7 @@ func containerTimeouts() timeouts.Value {
 	return timeouts.Value{
 		Object: types.ObjectNull(map[string]attr.Type{
 			"create": types.StringType,
+			"read":   types.StringType,
 			"update": types.StringType,
 			"delete": types.StringType,
 		}),
@@ -865,24 +895,7 @@ func containerTimeouts() timeouts.Value {
 }
 
 func buildContainerScalingObj() types.Object {
-	autoInputAttrTypes := map[string]attr.Type{
-		"minimal_replicas": types.Int64Type,
-		"maximal_replicas": types.Int64Type,
-		"triggers": types.ListType{ElemType: types.ObjectType{AttrTypes: map[string]attr.Type{
-			"type":      types.StringType,
-			"threshold": types.Int64Type,
-		}}},
-	}
-	scalingAttrTypes := map[string]attr.Type{
-		"type":         types.StringType,
-		"manual_input": types.Int64Type,
-		"auto_input":   types.ObjectType{AttrTypes: autoInputAttrTypes},
-	}
-	return types.ObjectValueMust(scalingAttrTypes, map[string]attr.Value{
-		"type":         types.StringValue("manual"),
-		"manual_input": types.Int64Value(1),
-		"auto_input":   types.ObjectNull(autoInputAttrTypes),
-	})
+	return manualScalingValue(1)
 }
 
 func buildContainerPlan(t *testing.T, namespace, name string) tfsdk.Plan {
@@ -898,10 +911,14 @@ func buildContainerPlan(t *testing.T, namespace, name string) tfsdk.Plan {
 		Namespace:            types.StringValue(namespace),
 		Image:                types.StringValue("nginx:latest"),
 		Registry:             types.StringNull(),
-		Resources:            types.StringValue("cpu250-ram500"),
+		Resources:            containerResourcesValue(0.25, 0.5),
 		Command:              types.ListNull(types.StringType),
 		Entrypoint:           types.ListNull(types.StringType),
 		EnvironmentVariables: types.SetNull(envVarObjectType()),
+		SecretEnvWO:          types.MapNull(types.StringType),
+		RedeployTrigger:      types.MapNull(types.StringType),
+		SecretEnvWOVersion:   types.Int64Null(),
+		EnvFrom:              types.ListNull(envFromObjectType()),
 		Ports:                types.ListNull(types.StringType),
 		Ingresses:            types.ListNull(IngressObjectType()),
 		ExternalConnection:   types.ObjectNull(ExternalConnectionWithPortsObjectAttributeTypes()),
@@ -928,10 +945,14 @@ func buildContainerState(t *testing.T, namespace, name string) tfsdk.State {
 		Namespace:            types.StringValue(namespace),
 		Image:                types.StringValue("nginx:latest"),
 		Registry:             types.StringNull(),
-		Resources:            types.StringValue("cpu250-ram500"),
+		Resources:            containerResourcesValue(0.25, 0.5),
 		Command:              types.ListNull(types.StringType),
 		Entrypoint:           types.ListNull(types.StringType),
 		EnvironmentVariables: types.SetNull(envVarObjectType()),
+		SecretEnvWO:          types.MapNull(types.StringType),
+		RedeployTrigger:      types.MapNull(types.StringType),
+		SecretEnvWOVersion:   types.Int64Null(),
+		EnvFrom:              types.ListNull(envFromObjectType()),
 		Ports:                types.ListNull(types.StringType),
 		Ingresses:            types.ListNull(IngressObjectType()),
 		ExternalConnection:   types.ObjectNull(ExternalConnectionWithPortsObjectAttributeTypes()),
@@ -976,12 +997,74 @@ func Test_ContainerCreate_api_error_surfaced(t *testing.T) {
 
 	r := &containerResource{nexaaClient: nexaaclient.NewWithAPI(m)}
 	resp := &resource.CreateResponse{}
-	r.Create(context.Background(), resource.CreateRequest{Plan: buildContainerPlan(t, "test-ns", "my-container")}, resp)
+	plan := buildContainerPlan(t, "test-ns", "my-container")
+	config := tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}
+	r.Create(context.Background(), resource.CreateRequest{Plan: plan, Config: config}, resp)
 
 	assert.True(t, resp.Diagnostics.HasError())
 	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "image not found")
 }
 
+func Test_ContainerUpdate_redeploy_trigger_sends_modify(t *testing.T) {
+	ctx := context.Background()
+	m := new(nexaaclient.MockNexaaAPI)
+	m.On("ListContainerByName", "test-ns", "my-container").Return(api.ContainerResult{}, nil)
+	m.On("ContainerModify", mock.Anything).Return(api.ContainerResult{}, errors.New("modify failed"))
+
+	plan := buildContainerPlan(t, "test-ns", "my-container")
+	require.False(t, plan.SetAttribute(ctx, path.Root("redeploy_trigger"), map[string]string{"release": "2"}).HasError())
+	state := buildContainerState(t, "test-ns", "my-container")
+	require.False(t, state.SetAttribute(ctx, path.Root("redeploy_trigger"), map[string]string{"release": "1"}).HasError())
+
+	r := &containerResource{nexaaClient: nexaaclient.NewWithAPI(m)}
+	resp := &resource.UpdateResponse{}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: state, Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}}, resp)
+
+	m.AssertCalled(t, "ContainerModify", mock.Anything)
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "modify failed")
+}
+
+func Test_ContainerSchema_redeploy_trigger_updates_in_place(t *testing.T) {
+	var sr resource.SchemaResponse
+	(&containerResource{}).Schema(context.Background(), resource.SchemaRequest{}, &sr)
+
+	attribute, ok := sr.Schema.Attributes["redeploy_trigger"].(schema.MapAttribute)
+	require.True(t, ok)
+	assert.Empty(t, attribute.PlanModifiers)
+}
+
+func Test_ContainerRead_sets_current_replicas(t *testing.T) {
+	ctx := context.Background()
+	m := new(nexaaclient.MockNexaaAPI)
+	m.On("ListContainerByName", "test-ns", "my-container").Return(api.ContainerResult{
+		Name:              "my-container",
+		Image:             "nginx:latest",
+		Resources:         api.ContainerResourcesCpu250Ram500,
+		State:             "starting",
+		NumberOfReplicas:  3,
+		AvailableReplicas: 2,
+	}, nil)
+
+	r := &containerResource{nexaaClient: nexaaclient.NewWithAPI(m)}
+	state := buildContainerState(t, "test-ns", "my-container")
+	var identitySchema resource.IdentitySchemaResponse
+	r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, &identitySchema)
+	resp := &resource.ReadResponse{
+		State: state,
+		Identity: &tfsdk.ResourceIdentity{
+			Schema: identitySchema.IdentitySchema,
+			Raw:    tftypes.NewValue(identitySchema.IdentitySchema.Type().TerraformType(ctx), nil),
+		},
+	}
+	r.Read(ctx, resource.ReadRequest{State: state}, resp)
+
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+	var current types.Int64
+	require.False(t, resp.State.GetAttribute(ctx, path.Root("current_replicas"), &current).HasError())
+	assert.Equal(t, types.Int64Value(2), current)
+}
+
 func Test_ContainerRead_api_error_surfaced(t *testing.T) {
 	m := new(nexaaclient.MockNexaaAPI)
 	m.On("ListContainerByName", "test-ns", "my-container").Return(api.ContainerResult{}, errors.New("internal server error"))
@@ -994,6 +1077,30 @@ func Test_ContainerRead_api_error_surfaced(t *testing.T) {
 	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "internal server error")
 }
 
+func Test_ContainerRead_not_found_removes_resource(t *testing.T) {
+	m := new(nexaaclient.MockNexaaAPI)
+	m.On("ListContainerByName", "test-ns", "my-container").Return(api.ContainerResult{}, errors.New("container not found"))
+
+	r := &containerResource{nexaaClient: nexaaclient.NewWithAPI(m)}
+	resp := &resource.ReadResponse{State: buildContainerState(t, "test-ns", "my-container")}
+	r.Read(context.Background(), resource.ReadRequest{State: buildContainerState(t, "test-ns", "my-container")}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	assert.True(t, resp.State.Raw.IsNull())
+}
+
+func Test_ContainerDelete_already_deleted_succeeds(t *testing.T) {
+	m := new(nexaaclient.MockNexaaAPI)
+	m.On("ListContainerByName", "test-ns", "my-container").Return(api.ContainerResult{}, errors.New("container not found"))
+
+	r := &containerResource{nexaaClient: nexaaclient.NewWithAPI(m)}
+	resp := &resource.DeleteResponse{}
+	r.Delete(context.Background(), resource.DeleteRequest{State: buildContainerState(t, "test-ns", "my-container")}, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	m.AssertNotCalled(t, "ContainerDelete", mock.Anything, mock.Anything)
+}
+
 // ── starter container ─────────────────────────────────────────────────────────
 
 func buildStarterContainerPlan(t *testing.T, namespace, name string) tfsdk.Plan {
@@ -1012,6 +1119,10 @@ func buildStarterContainerPlan(t *testing.T, namespace, name string) tfsdk.Plan
 		Command:              types.ListNull(types.StringType),
 		Entrypoint:           types.ListNull(types.StringType),
 		EnvironmentVariables: types.SetNull(envVarObjectType()),
+		SecretEnvWO:          types.MapNull(types.StringType),
+		SecretEnvWOVersion:   types.Int64Null(),
+		EnvFrom:              types.ListNull(envFromObjectType()),
+		RedeployTrigger:      types.MapNull(types.StringType),
 		Ports:                types.ListNull(types.StringType),
 		Ingresses:            types.ListNull(IngressObjectType()),
 		ExternalConnection:   types.ObjectNull(ExternalConnectionWithPortsObjectAttributeTypes()),
@@ -1040,6 +1151,10 @@ func buildStarterContainerState(t *testing.T, namespace, name string) tfsdk.Stat
 		Command:              types.ListNull(types.StringType),
 		Entrypoint:           types.ListNull(types.StringType),
 		EnvironmentVariables: types.SetNull(envVarObjectType()),
+		SecretEnvWO:          types.MapNull(types.StringType),
+		SecretEnvWOVersion:   types.Int64Null(),
+		EnvFrom:              types.ListNull(envFromObjectType()),
+		RedeployTrigger:      types.MapNull(types.StringType),
 		Ports:                types.ListNull(types.StringType),
 		Ingresses:            types.ListNull(IngressObjectType()),
 		ExternalConnection:   types.ObjectNull(ExternalConnectionWithPortsObjectAttributeTypes()),
@@ -1100,3 +1215,32 @@ func Test_StarterContainerRead_api_error_surfaced(t *testing.T) {
 	assert.True(t, resp.Diagnostics.HasError())
 	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "internal server error")
 }
+
+func Test_StarterContainerUpdate_redeploy_trigger_sends_modify(t *testing.T) {
+	ctx := context.Background()
+	m := new(nexaaclient.MockNexaaAPI)
+	m.On("ListContainerByName", "test-ns", "my-starter").Return(api.ContainerResult{}, nil)
+	m.On("ContainerModify", mock.Anything).Return(api.ContainerResult{}, errors.New("modify failed"))
+
+	plan := buildStarterContainerPlan(t, "test-ns", "my-starter")
+	require.False(t, plan.SetAttribute(ctx, path.Root("redeploy_trigger"), map[string]string{"release": "2"}).HasError())
+	state := buildStarterContainerState(t, "test-ns", "my-starter")
+	require.False(t, state.SetAttribute(ctx, path.Root("redeploy_trigger"), map[string]string{"release": "1"}).HasError())
+
+	r := &starterContainerResource{nexaaClient: nexaaclient.NewWithAPI(m)}
+	resp := &resource.UpdateResponse{}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: state, Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw}}, resp)
+
+	m.AssertCalled(t, "ContainerModify", mock.Anything)
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "modify failed")
+}
+
+func Test_StarterContainerSchema_redeploy_trigger_updates_in_place(t *testing.T) {
+	var sr resource.SchemaResponse
+	(&starterContainerResource{}).Schema(context.Background(), resource.SchemaRequest{}, &sr)
+
+	attribute, ok := sr.Schema.Attributes["redeploy_trigger"].(schema.MapAttribute)
+	require.True(t, ok)
+	assert.Empty(t, attribute.PlanModifiers)
+}
@@ -0,0 +1,76 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	nexaaclient "github.com/nexaa-cloud/terraform-provider-nexaa/internal/client"
+)
+
+// applyDefaultNamespace fills in the planned namespace from the provider's
+// default_namespace when the namespace attribute is omitted in the configuration.
+func applyDefaultNamespace(ctx context.Context, client *nexaaclient.NexaaClient, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do when the resource is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if deferUnknownNamespace(ctx, req, resp, path.Root("namespace")) {
+		return
+	}
+
+	var configured types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("namespace"), &configured)...)
+	if resp.Diagnostics.HasError() || !configured.IsNull() {
+		return
+	}
+
+	// The provider is not configured yet (e.g. its configuration depends on
+	// unknown values), leave the namespace unknown until it is.
+	if client == nil {
+		return
+	}
+
+	if client.DefaultNamespace == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("namespace"),
+			"Missing namespace",
+			"The namespace attribute is required when no default_namespace is set in the provider configuration.",
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("namespace"), client.DefaultNamespace)...)
+
+	// Resources can't be moved between namespaces, so a changed default
+	// namespace replaces the existing resource.
+	if !req.State.Raw.IsNull() {
+		var current types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("namespace"), &current)...)
+		if !current.IsNull() && current.ValueString() != client.DefaultNamespace {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("namespace"))
+		}
+	}
+}
+
+// deferUnknownNamespace defers the resource to a later plan and apply round
+// when the namespace at namespacePath is not known yet, e.g. because it is
+// computed by another module, and Terraform supports deferred actions.
+func deferUnknownNamespace(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, namespacePath path.Path) bool {
+	if !req.ClientCapabilities.DeferralAllowed || req.Plan.Raw.IsNull() {
+		return false
+	}
+
+	var namespace types.String
+	if diags := req.Config.GetAttribute(ctx, namespacePath, &namespace); diags.HasError() || !namespace.IsUnknown() {
+		return false
+	}
+
+	resp.Deferred = &resource.Deferred{Reason: resource.DeferredReasonResourceConfigUnknown}
+	return true
+}
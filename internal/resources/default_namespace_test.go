@@ -0,0 +1,162 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	nexaaclient "github.com/nexaa-cloud/terraform-provider-nexaa/internal/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func buildVolumeModifyPlanRequest(t *testing.T, configured types.String, state *string) (resource.ModifyPlanRequest, *resource.ModifyPlanResponse) {
+	t.Helper()
+	ctx := context.Background()
+	var sr resource.SchemaResponse
+	(&volumeResource{}).Schema(ctx, resource.SchemaRequest{}, &sr)
+
+	model := volumeResource{
+		ID:        types.StringUnknown(),
+		Name:      types.StringValue("data"),
+		Namespace: configured,
+		Size:      types.Int64Value(1),
+		Usage:     types.Float64Unknown(),
+		Locked:    types.BoolUnknown(),
+		Status:    types.StringUnknown(),
+		Timeouts:  volumeTimeouts(),
+	}
+
+	configPlan := tfsdk.Plan{Schema: sr.Schema}
+	require.False(t, configPlan.Set(ctx, &model).HasError())
+	config := tfsdk.Config{Schema: sr.Schema, Raw: configPlan.Raw}
+
+	if configured.IsNull() {
+		model.Namespace = types.StringUnknown()
+	}
+	plan := tfsdk.Plan{Schema: sr.Schema}
+	require.False(t, plan.Set(ctx, &model).HasError())
+
+	st := tfsdk.State{Schema: sr.Schema, Raw: tftypes.NewValue(sr.Schema.Type().TerraformType(ctx), nil)}
+	if state != nil {
+		model.Namespace = types.StringValue(*state)
+		require.False(t, st.Set(ctx, &model).HasError())
+	}
+
+	req := resource.ModifyPlanRequest{Config: config, Plan: plan, State: st}
+	return req, &resource.ModifyPlanResponse{Plan: plan}
+}
+
+func plannedNamespace(t *testing.T, resp *resource.ModifyPlanResponse) types.String {
+	t.Helper()
+	var ns types.String
+	require.False(t, resp.Plan.GetAttribute(context.Background(), path.Root("namespace"), &ns).HasError())
+	return ns
+}
+
+func Test_ApplyDefaultNamespace_uses_default_when_omitted(t *testing.T) {
+	client := nexaaclient.NewWithAPI(nil)
+	client.DefaultNamespace = "shared"
+	req, resp := buildVolumeModifyPlanRequest(t, types.StringNull(), nil)
+
+	applyDefaultNamespace(context.Background(), client, req, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "shared", plannedNamespace(t, resp).ValueString())
+	assert.Empty(t, resp.RequiresReplace)
+}
+
+func Test_ApplyDefaultNamespace_configured_namespace_wins(t *testing.T) {
+	client := nexaaclient.NewWithAPI(nil)
+	client.DefaultNamespace = "shared"
+	req, resp := buildVolumeModifyPlanRequest(t, types.StringValue("own"), nil)
+
+	applyDefaultNamespace(context.Background(), client, req, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "own", plannedNamespace(t, resp).ValueString())
+}
+
+func Test_ApplyDefaultNamespace_errors_without_default(t *testing.T) {
+	req, resp := buildVolumeModifyPlanRequest(t, types.StringNull(), nil)
+
+	applyDefaultNamespace(context.Background(), nexaaclient.NewWithAPI(nil), req, resp)
+
+	assert.True(t, resp.Diagnostics.HasError())
+}
+
+func Test_ApplyDefaultNamespace_unconfigured_provider_leaves_unknown(t *testing.T) {
+	req, resp := buildVolumeModifyPlanRequest(t, types.StringNull(), nil)
+
+	applyDefaultNamespace(context.Background(), nil, req, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	assert.True(t, plannedNamespace(t, resp).IsUnknown())
+}
+
+func Test_ApplyDefaultNamespace_changed_default_requires_replace(t *testing.T) {
+	client := nexaaclient.NewWithAPI(nil)
+	client.DefaultNamespace = "shared"
+	old := "previous"
+	req, resp := buildVolumeModifyPlanRequest(t, types.StringNull(), &old)
+
+	applyDefaultNamespace(context.Background(), client, req, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "shared", plannedNamespace(t, resp).ValueString())
+	assert.Contains(t, resp.RequiresReplace, path.Root("namespace"))
+}
+
+func Test_ApplyDefaultNamespace_defers_unknown_namespace(t *testing.T) {
+	req, resp := buildVolumeModifyPlanRequest(t, types.StringUnknown(), nil)
+	req.ClientCapabilities.DeferralAllowed = true
+
+	applyDefaultNamespace(context.Background(), nexaaclient.NewWithAPI(nil), req, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	require.NotNil(t, resp.Deferred)
+	assert.Equal(t, resource.DeferredReasonResourceConfigUnknown, resp.Deferred.Reason)
+}
+
+func Test_ApplyDefaultNamespace_no_deferral_without_client_support(t *testing.T) {
+	req, resp := buildVolumeModifyPlanRequest(t, types.StringUnknown(), nil)
+
+	applyDefaultNamespace(context.Background(), nexaaclient.NewWithAPI(nil), req, resp)
+
+	assert.False(t, resp.Diagnostics.HasError())
+	assert.Nil(t, resp.Deferred)
+}
+
+func Test_DeferUnknownNamespace_known_namespace(t *testing.T) {
+	req, resp := buildVolumeModifyPlanRequest(t, types.StringValue("ns"), nil)
+	req.ClientCapabilities.DeferralAllowed = true
+
+	assert.False(t, deferUnknownNamespace(context.Background(), req, resp, path.Root("namespace")))
+	assert.Nil(t, resp.Deferred)
+}
+
+func Test_DeferUnknownNamespace_unknown_cluster_namespace(t *testing.T) {
+	ctx := context.Background()
+	plan := buildCloudDBClusterDatabasePlan(t, "ns", "cluster", "db")
+	require.False(t, plan.SetAttribute(ctx, path.Root("cluster").AtName("namespace"), types.StringUnknown()).HasError())
+
+	req := resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: plan.Schema, Raw: plan.Raw},
+		Plan:   plan,
+		State:  tfsdk.State{Schema: plan.Schema, Raw: tftypes.NewValue(plan.Schema.Type().TerraformType(ctx), nil)},
+	}
+	req.ClientCapabilities.DeferralAllowed = true
+	resp := &resource.ModifyPlanResponse{Plan: plan}
+
+	(&cloudDatabaseClusterDatabaseResource{}).ModifyPlan(ctx, req, resp)
+
+	require.NotNil(t, resp.Deferred)
+	assert.Equal(t, resource.DeferredReasonResourceConfigUnknown, resp.Deferred.Reason)
+}
@@ -0,0 +1,177 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/nexaa-cloud/nexaa-cli/api"
+	nexaaclient "github.com/nexaa-cloud/terraform-provider-nexaa/internal/client"
+	"github.com/nexaa-cloud/terraform-provider-nexaa/internal/enums"
+)
+
+// defaultEnvFromPrefix is prepended to the injected variable names when no prefix is configured.
+const defaultEnvFromPrefix = "DATABASE_"
+
+type envFromResource struct {
+	CloudDatabaseClusterUser types.String `tfsdk:"cloud_database_cluster_user"`
+	Prefix                   types.String `tfsdk:"prefix"`
+}
+
+func envFromObjectAttributeTypes() map[string]attr.Type {
+	return map[string]attr.Type{
+		"cloud_database_cluster_user": types.StringType,
+		"prefix":                      types.StringType,
+	}
+}
+
+func envFromObjectType() types.ObjectType {
+	return types.ObjectType{AttrTypes: envFromObjectAttributeTypes()}
+}
+
+// envFromVariables returns the connection details of a database user as
+// environment variable inputs.
+func envFromVariables(prefix, hostname, specType, user, password string) ([]api.EnvironmentVariableInput, error) {
+	port, ok := enums.DatabasePorts[specType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported cloud database cluster type %q", specType)
+	}
+	vars := []api.EnvironmentVariableInput{
+		{Name: prefix + "HOST", Value: hostname, State: api.StatePresent},
+		{Name: prefix + "PORT", Value: strconv.FormatInt(port, 10), State: api.StatePresent},
+		{Name: prefix + "USER", Value: user, State: api.StatePresent},
+		{Name: prefix + "PASSWORD", Value: password, Secret: true, State: api.StatePresent},
+	}
+	return vars, nil
+}
+
+// dsnPassword returns the password in the DSN the API returns as the
+// credentials of a database user.
+func dsnPassword(dsn string) (string, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return "", errors.New("could not parse the credentials of the database user")
+	}
+	password, ok := u.User.Password()
+	if !ok || password == "" {
+		return "", errors.New("the credentials of the database user do not contain a password")
+	}
+	return password, nil
+}
+
+func envFromPrefix(ef envFromResource) string {
+	if ef.Prefix.IsNull() || ef.Prefix.IsUnknown() {
+		return defaultEnvFromPrefix
+	}
+	return ef.Prefix.ValueString()
+}
+
+// envFromNames returns the names of the variables an env_from list injects,
+// used to keep them out of environment_variables.
+func envFromNames(ctx context.Context, list types.List) map[string]bool {
+	names := map[string]bool{}
+	if list.IsNull() || list.IsUnknown() {
+		return names
+	}
+	var entries []envFromResource
+	_ = list.ElementsAs(ctx, &entries, false)
+	for _, ef := range entries {
+		prefix := envFromPrefix(ef)
+		for _, suffix := range []string{"HOST", "PORT", "USER", "PASSWORD"} {
+			names[prefix+suffix] = true
+		}
+	}
+	return names
+}
+
+// buildEnvFromInputs looks up the cluster of every referenced database user and
+// returns the environment variables to inject, rejecting names that are also
+// set through environment_variables.
+func buildEnvFromInputs(ctx context.Context, client nexaaclient.NexaaAPI, list types.List, regular []api.EnvironmentVariableInput) ([]api.EnvironmentVariableInput, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if list.IsNull() || list.IsUnknown() {
+		return nil, diags
+	}
+	var entries []envFromResource
+	if d := list.ElementsAs(ctx, &entries, false); d.HasError() {
+		return nil, d
+	}
+
+	taken := map[string]bool{}
+	for _, r := range regular {
+		if r.State == api.StatePresent {
+			taken[r.Name] = true
+		}
+	}
+
+	var inputs []api.EnvironmentVariableInput
+	for i, ef := range entries {
+		attrPath := path.Root("env_from").AtListIndex(i).AtName("cloud_database_cluster_user")
+		id, err := unpackCloudDatabaseClusterChildId(ef.CloudDatabaseClusterUser.ValueString())
+		if err != nil {
+			diags.AddAttributeError(attrPath, "Invalid database user reference", err.Error())
+			continue
+		}
+
+		clusterInput := api.CloudDatabaseClusterResourceInput{
+			Name:      id.Cluster,
+			Namespace: id.Namespace,
+		}
+		cluster, err := client.CloudDatabaseClusterGet(clusterInput)
+		if err != nil {
+			diags.AddAttributeError(attrPath, "Error reading cloud database cluster", "Could not read cluster "+id.Cluster+": "+err.Error())
+			continue
+		}
+		user, err := client.CloudDatabaseClusterUserGet(clusterInput, id.Name)
+		if err != nil {
+			diags.AddAttributeError(attrPath, "Error reading database user", "Could not read user "+id.Name+" in cluster "+id.Cluster+": "+err.Error())
+			continue
+		}
+
+		dsn, err := client.CloudDatabaseClusterUserCredentials(clusterInput, id.Name)
+		if err != nil {
+			diags.AddAttributeError(attrPath, "Error reading database user credentials", "Could not read the credentials of user "+id.Name+" in cluster "+id.Cluster+": "+err.Error())
+			continue
+		}
+		password, err := dsnPassword(dsn)
+		if err != nil {
+			diags.AddAttributeError(attrPath, "Error reading database user credentials", err.Error())
+			continue
+		}
+
+		vars, err := envFromVariables(envFromPrefix(ef), cluster.Hostname, cluster.Spec.GetType(), user.GetName(), password)
+		if err != nil {
+			diags.AddAttributeError(attrPath, "Error injecting database credentials", err.Error())
+			continue
+		}
+		for _, v := range vars {
+			if taken[v.Name] {
+				diags.AddAttributeError(attrPath, "Duplicate environment variable",
+					fmt.Sprintf("Environment variable %s injected by env_from is already set on the container", v.Name))
+				continue
+			}
+			taken[v.Name] = true
+			inputs = append(inputs, v)
+		}
+	}
+	return inputs, diags
+}
+
+// envInputPresent reports whether name is set in inputs.
+func envInputPresent(inputs []api.EnvironmentVariableInput, name string) bool {
+	for _, in := range inputs {
+		if in.Name == name && in.State == api.StatePresent {
+			return true
+		}
+	}
+	return false
+}
@@ -21,6 +21,10 @@ const (
 	secretMaskOnly                       // mask all secrets (Import)
 )
 
+// maskedSecretValue is stored for secret variables whose value is not known,
+// as the API does not return secret values.
+const maskedSecretValue = "***"
+
 // envVarObjectType returns the ObjectType used for environment variable elements.
 func envVarObjectType() types.ObjectType {
 	return types.ObjectType{AttrTypes: map[string]attr.Type{
@@ -130,16 +134,16 @@ func buildEnvSetFromAPI(ctx context.Context, apiVars []api.EnvironmentVariableRe
 				if v, ok := providedMap[ev.Name]; ok {
 					val = types.StringValue(v)
 				} else {
-					val = types.StringValue("***")
+					val = types.StringValue(maskedSecretValue)
 				}
 			case secretPreservePrev:
 				if v, ok := prevSecrets[ev.Name]; ok {
 					val = types.StringValue(v)
 				} else {
-					val = types.StringValue("***")
+					val = types.StringValue(maskedSecretValue)
 				}
 			case secretMaskOnly:
-				val = types.StringValue("***")
+				val = types.StringValue(maskedSecretValue)
 			}
 		} else if ev.Value != nil {
 			val = types.StringValue(*ev.Value)
@@ -38,6 +38,19 @@ func toStringArray(ctx context.Context, listInput types.List) []string {
 	return result
 }
 
+// setToStringArray is toStringArray for sets.
+func setToStringArray(ctx context.Context, setInput types.Set) []string {
+	result := []string{}
+	if setInput.IsNull() || setInput.IsUnknown() {
+		return result
+	}
+
+	_ = setInput.ElementsAs(ctx, &result, false)
+	sort.Strings(result)
+
+	return result
+}
+
 func toTypesStringList(ctx context.Context, stringArray []string) (types.List, diag.Diagnostics) {
 	list, diags := types.ListValueFrom(ctx, types.StringType, stringArray)
 	if diags.HasError() {
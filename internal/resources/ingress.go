@@ -5,6 +5,7 @@ package resources
 
 import (
 	"context"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -17,7 +18,7 @@ func IngressObjectAttributeTypes() map[string]attr.Type {
 		"domain_name": types.StringType,
 		"port":        types.Int64Type,
 		"tls":         types.BoolType,
-		"allowlist":   types.ListType{ElemType: types.StringType},
+		"allowlist":   types.SetType{ElemType: types.StringType},
 	}
 }
 
@@ -30,7 +31,7 @@ func buildIngressElem(ing api.ContainerResultIngressesIngress) (attr.Value, diag
 	for i, a := range ing.Allowlist {
 		allowListElems[i] = types.StringValue(a)
 	}
-	allowList, diags := types.ListValue(types.StringType, allowListElems)
+	allowList, diags := types.SetValue(types.StringType, allowListElems)
 	if diags.HasError() {
 		return nil, diags
 	}
@@ -66,7 +67,8 @@ func buildIngressesFromApi(containerResult api.ContainerResult) (types.List, dia
 }
 
 // buildIngressesFromApiInPlanOrder returns API ingresses in the same order as knownIngresses,
-// matched by domain_name. Falls back to plain API order when any domain_name is unknown.
+// matched by domain_name as described on sameDomainName. Matched ingresses keep the known
+// domain_name. Falls back to plain API order when any domain_name is unknown.
 func buildIngressesFromApiInPlanOrder(ctx context.Context, containerResult api.ContainerResult, knownIngresses types.List) (types.List, diag.Diagnostics) {
 	if knownIngresses.IsNull() || knownIngresses.IsUnknown() {
 		return buildIngressesFromApi(containerResult)
@@ -84,24 +86,27 @@ func buildIngressesFromApiInPlanOrder(ctx context.Context, containerResult api.C
 		}
 	}
 
-	apiByDomain := make(map[string]api.ContainerResultIngressesIngress)
+	var apiIngresses []api.ContainerResultIngressesIngress
 	for _, ing := range containerResult.Ingresses {
 		if ing.State == "to_be_deleted" || ing.State == "deleting" {
 			continue
 		}
-		apiByDomain[ing.DomainName] = ing
+		apiIngresses = append(apiIngresses, ing)
 	}
 
-	seen := make(map[string]bool)
+	seen := make([]bool, len(apiIngresses))
 	var ingressElems []attr.Value
 
 	for _, known := range knownData {
 		domain := known.DomainName.ValueString()
-		apiIng, ok := apiByDomain[domain]
-		if !ok {
+		i := matchIngressDomain(apiIngresses, seen, domain)
+		if i < 0 {
 			continue
 		}
-		seen[domain] = true
+		seen[i] = true
+		// Keep the known spelling, the API returns the domain normalized.
+		apiIng := apiIngresses[i]
+		apiIng.DomainName = domain
 		elem, d := buildIngressElem(apiIng)
 		diags.Append(d...)
 		if diags.HasError() {
@@ -110,8 +115,8 @@ func buildIngressesFromApiInPlanOrder(ctx context.Context, containerResult api.C
 		ingressElems = append(ingressElems, elem)
 	}
 
-	for domain, apiIng := range apiByDomain {
-		if seen[domain] {
+	for i, apiIng := range apiIngresses {
+		if seen[i] {
 			continue
 		}
 		elem, d := buildIngressElem(apiIng)
@@ -126,3 +131,38 @@ func buildIngressesFromApiInPlanOrder(ctx context.Context, containerResult api.C
 	diags.Append(d...)
 	return list, diags
 }
+
+// matchIngressDomain returns the index of the first ingress not yet seen whose
+// domain is the same as domain, preferring an exact match, or -1.
+func matchIngressDomain(ingresses []api.ContainerResultIngressesIngress, seen []bool, domain string) int {
+	match := -1
+	for i, ing := range ingresses {
+		if seen[i] {
+			continue
+		}
+		if ing.DomainName == domain {
+			return i
+		}
+		if match < 0 && sameDomainName(domain, ing.DomainName) {
+			match = i
+		}
+	}
+	return match
+}
+
+// normalizeDomainName lowercases a domain and strips the trailing dot of a
+// fully qualified name, as the API does.
+func normalizeDomainName(domain string) string {
+	return strings.TrimSuffix(strings.ToLower(domain), ".")
+}
+
+// sameDomainName reports whether the API stores configured as actual. Besides
+// normalizing, the API appends the default domain to a single label.
+func sameDomainName(configured, actual string) bool {
+	configured = normalizeDomainName(configured)
+	actual = normalizeDomainName(actual)
+	if configured == actual {
+		return true
+	}
+	return configured != "" && !strings.Contains(configured, ".") && strings.HasPrefix(actual, configured+".")
+}
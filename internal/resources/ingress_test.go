@@ -76,7 +76,7 @@ func Test_BuildIngressesFromApi_multiple_mixed_states(t *testing.T) {
 func makeKnownIngressList(domains ...string) types.List {
 	elems := make([]attr.Value, len(domains))
 	for i, d := range domains {
-		allowlist := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("0.0.0.0/0")})
+		allowlist := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("0.0.0.0/0")})
 		elems[i] = types.ObjectValueMust(IngressObjectAttributeTypes(), map[string]attr.Value{
 			"domain_name": types.StringValue(d),
 			"port":        types.Int64Value(80),
@@ -95,7 +95,7 @@ func Test_BuildIngressesFromApiInPlanOrder_null_known_falls_back(t *testing.T) {
 }
 
 func Test_BuildIngressesFromApiInPlanOrder_unknown_domain_falls_back_to_plain_order(t *testing.T) {
-	allowlist := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("0.0.0.0/0")})
+	allowlist := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("0.0.0.0/0")})
 	unknownElem := types.ObjectValueMust(IngressObjectAttributeTypes(), map[string]attr.Value{
 		"domain_name": types.StringUnknown(),
 		"port":        types.Int64Value(80),
@@ -143,3 +143,63 @@ func Test_BuildIngressesFromApiInPlanOrder_plan_entry_missing_from_api_dropped(t
 	assert.False(t, diags.HasError())
 	assert.Equal(t, 1, len(result.Elements()))
 }
+
+func Test_BuildIngressesFromApiInPlanOrder_keeps_known_domain_spelling(t *testing.T) {
+	cr := makeContainerResult(
+		makeAPIIngress("app.example.com", 80, "present"),
+		makeAPIIngress("web.container.tilaa.cloud", 80, "present"),
+	)
+	known := makeKnownIngressList("App.Example.com.", "web")
+	result, diags := buildIngressesFromApiInPlanOrder(context.Background(), cr, known)
+	assert.False(t, diags.HasError())
+	elems := result.Elements()
+	assert.Equal(t, 2, len(elems))
+	assert.Equal(t, types.StringValue("App.Example.com."), elems[0].(types.Object).Attributes()["domain_name"])
+	assert.Equal(t, types.StringValue("web"), elems[1].(types.Object).Attributes()["domain_name"])
+}
+
+// --- sameDomainName ---
+
+func Test_SameDomainName(t *testing.T) {
+	cases := []struct {
+		configured, actual string
+		want               bool
+	}{
+		{"app.example.com", "app.example.com", true},
+		{"App.Example.COM", "app.example.com", true},
+		{"app.example.com.", "app.example.com", true},
+		{"web", "web.container.tilaa.cloud", true},
+		{"web", "webshop.container.tilaa.cloud", false},
+		{"app.example.com", "app.example.com.container.tilaa.cloud", false},
+		{"app.example.com", "api.example.com", false},
+		{"", "app.example.com", false},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.want, sameDomainName(c.configured, c.actual), "%q vs %q", c.configured, c.actual)
+	}
+}
+
+// --- buildIngressesUpdateInput ---
+
+func Test_BuildIngressesUpdateInput_normalized_domain_not_removed(t *testing.T) {
+	current := makeKnownIngressList("App.Example.com")
+	previous := makeKnownIngressList("app.example.com")
+
+	inputs, diags := buildIngressesUpdateInput(context.Background(), current, previous)
+
+	assert.False(t, diags.HasError())
+	assert.Equal(t, 1, len(inputs))
+	assert.Equal(t, api.StatePresent, inputs[0].State)
+}
+
+func Test_BuildIngressesUpdateInput_removed_domain_marked_absent(t *testing.T) {
+	current := makeKnownIngressList("a.example.com")
+	previous := makeKnownIngressList("a.example.com", "b.example.com")
+
+	inputs, diags := buildIngressesUpdateInput(context.Background(), current, previous)
+
+	assert.False(t, diags.HasError())
+	assert.Equal(t, 2, len(inputs))
+	assert.Equal(t, "b.example.com", *inputs[1].DomainName)
+	assert.Equal(t, api.StateAbsent, inputs[1].State)
+}
@@ -4,6 +4,8 @@
 package resources
 
 import (
+	"context"
+
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -13,8 +15,10 @@ import (
 
 func MountsObjectAttributeTypes() map[string]attr.Type {
 	return map[string]attr.Type{
-		"path":   types.StringType,
-		"volume": types.StringType,
+		"path":        types.StringType,
+		"volume":      types.StringType,
+		"auto_create": types.BoolType,
+		"size":        types.Int64Type,
 	}
 }
 
@@ -22,14 +26,42 @@ func MountsObjectType() types.ObjectType {
 	return types.ObjectType{AttrTypes: MountsObjectAttributeTypes()}
 }
 
-func buildMountsFromApi(mounts []api.ContainerMounts) (basetypes.ListValue, diag.Diagnostics) {
+func mountKey(path, volume string) string {
+	return path + "|" + volume
+}
+
+// buildMountsFromApi converts API mounts to a Terraform list. The API does not
+// return auto_create and size, so they are taken from the previous mounts with
+// the same path and volume.
+func buildMountsFromApi(ctx context.Context, mounts []api.ContainerMounts, previous types.List) (basetypes.ListValue, diag.Diagnostics) {
+	prevMounts := map[string]mountResource{}
+	if !previous.IsNull() && !previous.IsUnknown() {
+		var prev []mountResource
+		_ = previous.ElementsAs(ctx, &prev, false)
+		for _, m := range prev {
+			prevMounts[mountKey(m.Path.ValueString(), m.Volume.ValueString())] = m
+		}
+	}
+
 	result := make([]attr.Value, len(mounts))
 	for i, m := range mounts {
+		autoCreate := types.BoolValue(false)
+		size := types.Int64Null()
+		if prev, ok := prevMounts[mountKey(m.Path, m.Volume.Name)]; ok {
+			if !prev.AutoCreate.IsNull() && !prev.AutoCreate.IsUnknown() {
+				autoCreate = prev.AutoCreate
+			}
+			if !prev.Size.IsUnknown() {
+				size = prev.Size
+			}
+		}
 		obj := types.ObjectValueMust(
 			MountsObjectAttributeTypes(),
 			map[string]attr.Value{
-				"path":   types.StringValue(m.Path),
-				"volume": types.StringValue(m.Volume.Name),
+				"path":        types.StringValue(m.Path),
+				"volume":      types.StringValue(m.Volume.Name),
+				"auto_create": autoCreate,
+				"size":        size,
 			})
 		result[i] = obj
 	}
@@ -39,3 +71,23 @@ func buildMountsFromApi(mounts []api.ContainerMounts) (basetypes.ListValue, diag
 		result,
 	)
 }
+
+// buildMountVolumeInput returns the volume input of a planned mount. The
+// volume is only increased when its size grew compared to the previous mount.
+func buildMountVolumeInput(m mountResource, previous *mountResource) api.MountVolumeInput {
+	input := api.MountVolumeInput{
+		Name:       m.Volume.ValueString(),
+		AutoCreate: m.AutoCreate.ValueBool(),
+		Increase:   false,
+		Size:       nil,
+	}
+	if m.Size.IsNull() || m.Size.IsUnknown() {
+		return input
+	}
+	size := int(m.Size.ValueInt64())
+	input.Size = &size
+	if previous != nil && !previous.Size.IsNull() && previous.Size.ValueInt64() < m.Size.ValueInt64() {
+		input.Increase = true
+	}
+	return input
+}
@@ -16,7 +16,7 @@ import (
 // --- buildMountsFromApi ---
 
 func Test_BuildMountsFromApi_empty(t *testing.T) {
-	result, diags := buildMountsFromApi([]api.ContainerMounts{})
+	result, diags := buildMountsFromApi(context.Background(), []api.ContainerMounts{}, types.ListNull(MountsObjectType()))
 	assert.False(t, diags.HasError())
 	assert.Equal(t, 0, len(result.Elements()))
 }
@@ -25,14 +25,16 @@ func Test_BuildMountsFromApi_single_mount(t *testing.T) {
 	mounts := []api.ContainerMounts{
 		{Path: "/data", Volume: api.ContainerMountsVolume{Name: "my-vol"}},
 	}
-	result, diags := buildMountsFromApi(mounts)
+	result, diags := buildMountsFromApi(context.Background(), mounts, types.ListNull(MountsObjectType()))
 	assert.False(t, diags.HasError())
 	assert.Equal(t, 1, len(result.Elements()))
 
 	expected := types.ListValueMust(MountsObjectType(), []attr.Value{
 		types.ObjectValueMust(MountsObjectAttributeTypes(), map[string]attr.Value{
-			"path":   types.StringValue("/data"),
-			"volume": types.StringValue("my-vol"),
+			"path":        types.StringValue("/data"),
+			"volume":      types.StringValue("my-vol"),
+			"auto_create": types.BoolValue(false),
+			"size":        types.Int64Null(),
 		}),
 	})
 	assert.Equal(t, expected, result)
@@ -43,7 +45,7 @@ func Test_BuildMountsFromApi_multiple_mounts(t *testing.T) {
 		{Path: "/data", Volume: api.ContainerMountsVolume{Name: "vol-1"}},
 		{Path: "/logs", Volume: api.ContainerMountsVolume{Name: "vol-2"}},
 	}
-	result, diags := buildMountsFromApi(mounts)
+	result, diags := buildMountsFromApi(context.Background(), mounts, types.ListNull(MountsObjectType()))
 	assert.False(t, diags.HasError())
 	assert.Equal(t, 2, len(result.Elements()))
 }
@@ -59,8 +61,10 @@ func Test_BuildMountsInput_null_list(t *testing.T) {
 func Test_BuildMountsInput_single_mount(t *testing.T) {
 	list := types.ListValueMust(MountsObjectType(), []attr.Value{
 		types.ObjectValueMust(MountsObjectAttributeTypes(), map[string]attr.Value{
-			"path":   types.StringValue("/data"),
-			"volume": types.StringValue("my-vol"),
+			"path":        types.StringValue("/data"),
+			"volume":      types.StringValue("my-vol"),
+			"auto_create": types.BoolValue(false),
+			"size":        types.Int64Null(),
 		}),
 	})
 	result, diags := buildMountsInput(context.Background(), list)
@@ -74,12 +78,16 @@ func Test_BuildMountsInput_single_mount(t *testing.T) {
 func Test_BuildMountsInput_multiple_mounts(t *testing.T) {
 	list := types.ListValueMust(MountsObjectType(), []attr.Value{
 		types.ObjectValueMust(MountsObjectAttributeTypes(), map[string]attr.Value{
-			"path":   types.StringValue("/data"),
-			"volume": types.StringValue("vol-1"),
+			"path":        types.StringValue("/data"),
+			"volume":      types.StringValue("vol-1"),
+			"auto_create": types.BoolValue(false),
+			"size":        types.Int64Null(),
 		}),
 		types.ObjectValueMust(MountsObjectAttributeTypes(), map[string]attr.Value{
-			"path":   types.StringValue("/logs"),
-			"volume": types.StringValue("vol-2"),
+			"path":        types.StringValue("/logs"),
+			"volume":      types.StringValue("vol-2"),
+			"auto_create": types.BoolValue(false),
+			"size":        types.Int64Null(),
 		}),
 	})
 	result, diags := buildMountsInput(context.Background(), list)
@@ -88,3 +96,36 @@ func Test_BuildMountsInput_multiple_mounts(t *testing.T) {
 	assert.Equal(t, "/data", result[0].Path)
 	assert.Equal(t, "/logs", result[1].Path)
 }
+
+func Test_BuildMountsFromApi_keeps_previous_options(t *testing.T) {
+	previous := types.ListValueMust(MountsObjectType(), []attr.Value{
+		types.ObjectValueMust(MountsObjectAttributeTypes(), map[string]attr.Value{
+			"path":        types.StringValue("/data"),
+			"volume":      types.StringValue("my-vol"),
+			"auto_create": types.BoolValue(true),
+			"size":        types.Int64Value(5),
+		}),
+	})
+	mounts := []api.ContainerMounts{
+		{Path: "/data", Volume: api.ContainerMountsVolume{Name: "my-vol"}},
+	}
+	result, diags := buildMountsFromApi(context.Background(), mounts, previous)
+	assert.False(t, diags.HasError())
+	assert.Equal(t, previous, result)
+}
+
+func Test_BuildMountsInput_auto_create(t *testing.T) {
+	list := types.ListValueMust(MountsObjectType(), []attr.Value{
+		types.ObjectValueMust(MountsObjectAttributeTypes(), map[string]attr.Value{
+			"path":        types.StringValue("/data"),
+			"volume":      types.StringValue("my-vol"),
+			"auto_create": types.BoolValue(true),
+			"size":        types.Int64Value(5),
+		}),
+	})
+	result, diags := buildMountsInput(context.Background(), list)
+	assert.False(t, diags.HasError())
+	assert.True(t, result[0].Volume.AutoCreate)
+	assert.Equal(t, 5, *result[0].Volume.Size)
+	assert.False(t, result[0].Volume.Increase)
+}
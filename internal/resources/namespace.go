@@ -85,6 +85,57 @@ func waitForAllChildrenToBeRemoved(ctx context.Context, client nexaaclient.Nexaa
 	return nil
 }
 
+// namespaceDeletionGracePeriod is how long deleting a namespace that must be
+// empty waits for resources removed earlier in the same apply to disappear.
+const namespaceDeletionGracePeriod = time.Minute
+
+// namespaceResourceNames lists the resources in a namespace as "type name".
+func namespaceResourceNames(client nexaaclient.NexaaAPI, namespace api.NamespaceResult) ([]string, error) {
+	var names []string
+	for _, c := range namespace.Containers {
+		names = append(names, "container "+c.Name)
+	}
+	for _, j := range namespace.ContainerJobs {
+		names = append(names, "container job "+j.Name)
+	}
+	for _, v := range namespace.Volumes {
+		names = append(names, "volume "+v.Name)
+	}
+	for _, c := range namespace.CloudDatabaseClusters {
+		names = append(names, "cloud database cluster "+c.Name)
+	}
+	for _, r := range namespace.PrivateRegistries {
+		names = append(names, "registry "+r.Name)
+	}
+
+	queues, err := client.MessageQueueList()
+	if err != nil {
+		return nil, err
+	}
+	for _, q := range queues {
+		if q.GetNamespace().Name == namespace.Name {
+			names = append(names, "message queue "+q.Name)
+		}
+	}
+	return names, nil
+}
+
+// namespaceContainsResourcesDetail explains why a namespace is not deleted,
+// listing its resources when they can be fetched.
+func namespaceContainsResourcesDetail(client nexaaclient.NexaaAPI, namespaceName string) string {
+	detail := "Namespace " + namespaceName + " still contains resources and prevent_deletion_if_contains_resources is enabled in the provider features block"
+
+	namespace, err := client.NamespaceListByName(namespaceName)
+	if err != nil {
+		return detail
+	}
+	names, err := namespaceResourceNames(client, namespace)
+	if err != nil || len(names) == 0 {
+		return detail
+	}
+	return detail + ": " + strings.Join(names, ", ")
+}
+
 func deleteNamespaceWithRetry(ctx context.Context, client nexaaclient.NexaaAPI, namespaceName string) error {
 	const (
 		initialDelay = 5 * time.Second
@@ -7,6 +7,8 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/nexaa-cloud/nexaa-cli/api"
+	nexaaclient "github.com/nexaa-cloud/terraform-provider-nexaa/internal/client"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -25,3 +27,43 @@ func Test_IsCannotBeDeletedErr_matching_lowercase(t *testing.T) {
 func Test_IsCannotBeDeletedErr_matching_mixed_case(t *testing.T) {
 	assert.True(t, isCannotBeDeletedErr(errors.New("Cannot Be Deleted")))
 }
+
+func Test_NamespaceResourceNames_lists_all_types(t *testing.T) {
+	m := new(nexaaclient.MockNexaaAPI)
+	m.On("MessageQueueList").Return([]api.MessageQueueResult{
+		{Name: "mq", Namespace: api.MessageQueueResultNamespace{Name: "ns"}},
+		{Name: "other-mq", Namespace: api.MessageQueueResultNamespace{Name: "other"}},
+	}, nil)
+
+	names, err := namespaceResourceNames(m, api.NamespaceResult{
+		Name:       "ns",
+		Containers: []api.NamespaceResultContainersContainer{{Name: "web"}},
+		Volumes:    []api.NamespaceResultVolumesVolume{{Name: "data"}},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"container web", "volume data", "message queue mq"}, names)
+}
+
+func Test_NamespaceContainsResourcesDetail_lists_resources(t *testing.T) {
+	m := new(nexaaclient.MockNexaaAPI)
+	m.On("NamespaceListByName", "ns").Return(api.NamespaceResult{
+		Name:              "ns",
+		PrivateRegistries: []api.NamespaceResultPrivateRegistriesPrivateRegistry{{Name: "reg"}},
+	}, nil)
+	m.On("MessageQueueList").Return([]api.MessageQueueResult{}, nil)
+
+	detail := namespaceContainsResourcesDetail(m, "ns")
+
+	assert.Contains(t, detail, "prevent_deletion_if_contains_resources")
+	assert.Contains(t, detail, "registry reg")
+}
+
+func Test_NamespaceContainsResourcesDetail_lookup_error(t *testing.T) {
+	m := new(nexaaclient.MockNexaaAPI)
+	m.On("NamespaceListByName", "ns").Return(api.NamespaceResult{}, errors.New("connection refused"))
+
+	detail := namespaceContainsResourcesDetail(m, "ns")
+
+	assert.Contains(t, detail, "Namespace ns still contains resources")
+}
@@ -10,9 +10,11 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -21,6 +23,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/nexaa-cloud/nexaa-cli/api"
 	nexaaclient "github.com/nexaa-cloud/terraform-provider-nexaa/internal/client"
+	"github.com/nexaa-cloud/terraform-provider-nexaa/internal/tracing"
 )
 
 var (
@@ -28,6 +31,7 @@ var (
 	_ resource.ResourceWithImportState = &cloudDatabaseClusterResource{}
 	_ resource.ResourceWithIdentity    = &cloudDatabaseClusterResource{}
 	_ resource.ResourceWithConfigure   = &cloudDatabaseClusterResource{}
+	_ resource.ResourceWithModifyPlan  = &cloudDatabaseClusterResource{}
 )
 
 func NewCloudDatabaseClusterResource() resource.Resource {
@@ -54,8 +58,11 @@ type cloudDatabaseClusterResource struct {
 	Spec               Spec           `tfsdk:"spec"`
 	Plan               types.String   `tfsdk:"plan"`
 	Hostname           types.String   `tfsdk:"hostname"`
+	Port               types.Int64    `tfsdk:"port"`
+	PrimaryEndpoint    types.String   `tfsdk:"primary_endpoint"`
 	ExternalConnection types.Object   `tfsdk:"external_connection"`
 	State              types.String   `tfsdk:"state"`
+	DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
 	Timeouts           timeouts.Value `tfsdk:"timeouts"`
 }
 
@@ -74,6 +81,11 @@ func (r *cloudDatabaseClusterResource) Metadata(_ context.Context, req resource.
 	resp.TypeName = req.ProviderTypeName + "_cloud_database_cluster"
 }
 
+// ModifyPlan defers the resource while the namespace of its cluster is unknown.
+func (r *cloudDatabaseClusterResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	deferUnknownNamespace(ctx, req, resp, path.Root("cluster").AtName("namespace"))
+}
+
 func (r *cloudDatabaseClusterResource) IdentitySchema(ctx context.Context, request resource.IdentitySchemaRequest, response *resource.IdentitySchemaResponse) {
 	response.IdentitySchema = identityschema.Schema{
 		Attributes: map[string]identityschema.Attribute{
@@ -120,6 +132,14 @@ func (r *cloudDatabaseClusterResource) Schema(ctx context.Context, _ resource.Sc
 				Computed:    true,
 				Description: "Hostname of the cloud database cluster",
 			},
+			"port": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Port the cloud database cluster listens on within the namespace",
+			},
+			"primary_endpoint": schema.StringAttribute{
+				Computed:    true,
+				Description: "Endpoint of the primary of the cloud database cluster, in the format hostname:port",
+			},
 			"external_connection": schema.SingleNestedAttribute{
 				Attributes: map[string]schema.Attribute{
 					"ipv4": schema.StringAttribute{
@@ -169,6 +189,12 @@ func (r *cloudDatabaseClusterResource) Schema(ctx context.Context, _ resource.Sc
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"deletion_protection": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Prevent the cloud database cluster from being deleted. Destroying the cluster fails until this is set to false and applied",
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"timeouts": timeouts.Block(ctx, timeouts.Opts{
@@ -181,6 +207,9 @@ func (r *cloudDatabaseClusterResource) Schema(ctx context.Context, _ resource.Sc
 }
 
 func (r *cloudDatabaseClusterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_cloud_database_cluster.Create")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var plan cloudDatabaseClusterResource
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -241,7 +270,7 @@ func (r *cloudDatabaseClusterResource) Create(ctx context.Context, req resource.
 		return
 	}
 
-	err = waitForUnlocked(ctx, cloudDatabaseClusterLocked(), client, plan.Cluster.Namespace.ValueString(), plan.Cluster.Name.ValueString())
+	err = waitForUnlocked(ctx, cloudDatabaseClusterLocked(), client, r.nexaaClient.PollInterval, plan.Cluster.Namespace.ValueString(), plan.Cluster.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating cluster", "Could not reach a unlocked state: "+err.Error())
 		return
@@ -260,7 +289,7 @@ func (r *cloudDatabaseClusterResource) Create(ctx context.Context, req resource.
 		return
 	}
 
-	plan, diags = translateApiToCloudDatabaseClusterResource(ctx, cluster, plan.Timeouts)
+	plan, diags = translateApiToCloudDatabaseClusterResource(ctx, cluster, plan.DeletionProtection, plan.Timeouts)
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
 		return
@@ -283,6 +312,8 @@ func (r *cloudDatabaseClusterResource) Create(ctx context.Context, req resource.
 }
 
 func (r *cloudDatabaseClusterResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_cloud_database_cluster.Read")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
 
 	var plan cloudDatabaseClusterResource
 	diags := req.State.Get(ctx, &plan)
@@ -310,7 +341,7 @@ func (r *cloudDatabaseClusterResource) Read(ctx context.Context, req resource.Re
 		return
 	}
 
-	plan, diags = translateApiToCloudDatabaseClusterResource(ctx, cluster, plan.Timeouts)
+	plan, diags = translateApiToCloudDatabaseClusterResource(ctx, cluster, plan.DeletionProtection, plan.Timeouts)
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
 		return
@@ -335,6 +366,9 @@ func (r *cloudDatabaseClusterResource) Read(ctx context.Context, req resource.Re
 
 // Omitting is not fully supported for this resource. So we write the current state back unchanged and only change the external connection.
 func (r *cloudDatabaseClusterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_cloud_database_cluster.Update")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	// No in-place updates supported; preserve current plan.
 	var plan cloudDatabaseClusterResource
 	diags := req.Plan.Get(ctx, &plan)
@@ -381,7 +415,7 @@ func (r *cloudDatabaseClusterResource) Update(ctx context.Context, req resource.
 		return
 	}
 
-	err = waitForUnlocked(ctx, cloudDatabaseClusterLocked(), client, plan.Cluster.Namespace.ValueString(), plan.Cluster.Name.ValueString())
+	err = waitForUnlocked(ctx, cloudDatabaseClusterLocked(), client, r.nexaaClient.PollInterval, plan.Cluster.Namespace.ValueString(), plan.Cluster.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating cluster", "Could not reach a unlocked state: "+err.Error())
 		return
@@ -400,7 +434,7 @@ func (r *cloudDatabaseClusterResource) Update(ctx context.Context, req resource.
 		return
 	}
 
-	plan, diags = translateApiToCloudDatabaseClusterResource(ctx, cluster, plan.Timeouts)
+	plan, diags = translateApiToCloudDatabaseClusterResource(ctx, cluster, plan.DeletionProtection, plan.Timeouts)
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
 		return
@@ -425,6 +459,9 @@ func (r *cloudDatabaseClusterResource) Update(ctx context.Context, req resource.
 }
 
 func (r *cloudDatabaseClusterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_cloud_database_cluster.Delete")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var plan cloudDatabaseClusterResource
 	diags := req.State.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -432,6 +469,15 @@ func (r *cloudDatabaseClusterResource) Delete(ctx context.Context, req resource.
 		return
 	}
 
+	if plan.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Cloud database cluster is protected from deletion",
+			"Cloud database cluster "+plan.Cluster.Name.ValueString()+" in namespace "+plan.Cluster.Namespace.ValueString()+" has deletion_protection enabled. "+
+				"Set deletion_protection to false and apply before destroying it.",
+		)
+		return
+	}
+
 	deleteTimeout, diags := plan.Timeouts.Delete(ctx, 2*time.Minute)
 
 	resp.Diagnostics.Append(diags...)
@@ -444,7 +490,7 @@ func (r *cloudDatabaseClusterResource) Delete(ctx context.Context, req resource.
 	defer cancel()
 
 	client := r.nexaaClient.API
-	err := waitForUnlocked(ctx, cloudDatabaseClusterLocked(), client, plan.Cluster.Namespace.ValueString(), plan.Cluster.Name.ValueString())
+	err := waitForUnlocked(ctx, cloudDatabaseClusterLocked(), client, r.nexaaClient.PollInterval, plan.Cluster.Namespace.ValueString(), plan.Cluster.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting cluster", "Could not reach a unlocked state: "+err.Error())
 		return
@@ -506,7 +552,7 @@ func (r *cloudDatabaseClusterResource) ImportState(ctx context.Context, req reso
 		),
 	}
 
-	plan, diags := translateApiToCloudDatabaseClusterResource(ctx, cluster, plan.Timeouts)
+	plan, diags := translateApiToCloudDatabaseClusterResource(ctx, cluster, plan.DeletionProtection, plan.Timeouts)
 	if diags.HasError() {
 		resp.Diagnostics.Append(diags...)
 		return
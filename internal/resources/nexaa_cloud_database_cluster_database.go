@@ -12,7 +12,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/nexaa-cloud/nexaa-cli/api"
 	nexaaclient "github.com/nexaa-cloud/terraform-provider-nexaa/internal/client"
+	"github.com/nexaa-cloud/terraform-provider-nexaa/internal/tracing"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -24,6 +26,7 @@ var (
 	_ resource.Resource                = &cloudDatabaseClusterDatabaseResource{}
 	_ resource.ResourceWithImportState = &cloudDatabaseClusterDatabaseResource{}
 	_ resource.ResourceWithConfigure   = &cloudDatabaseClusterDatabaseResource{}
+	_ resource.ResourceWithModifyPlan  = &cloudDatabaseClusterDatabaseResource{}
 )
 
 func NewCloudDatabaseClusterDatabaseResource() resource.Resource {
@@ -56,6 +59,11 @@ func (r *cloudDatabaseClusterDatabaseResource) Metadata(_ context.Context, req r
 	resp.TypeName = req.ProviderTypeName + "_cloud_database_cluster_database"
 }
 
+// ModifyPlan defers the resource while the namespace of its cluster is unknown.
+func (r *cloudDatabaseClusterDatabaseResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	deferUnknownNamespace(ctx, req, resp, path.Root("cluster").AtName("namespace"))
+}
+
 func (r *cloudDatabaseClusterDatabaseResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Database resource representing a database within a cloud database cluster on Nexaa.",
@@ -95,6 +103,9 @@ func (r *cloudDatabaseClusterDatabaseResource) Schema(_ context.Context, _ resou
 }
 
 func (r *cloudDatabaseClusterDatabaseResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_cloud_database_cluster_database.Create")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var plan cloudDatabaseClusterDatabaseResource
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -114,7 +125,7 @@ func (r *cloudDatabaseClusterDatabaseResource) Create(ctx context.Context, req r
 	defer cancel()
 
 	client := r.nexaaClient.API
-	err := waitForUnlocked(ctx, cloudDatabaseClusterLocked(), client, plan.Cluster.Namespace.ValueString(), plan.Cluster.Name.ValueString())
+	err := waitForUnlocked(ctx, cloudDatabaseClusterLocked(), client, r.nexaaClient.PollInterval, plan.Cluster.Namespace.ValueString(), plan.Cluster.Name.ValueString())
 
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating database", "Cloud database cluster is not ready yet: "+err.Error())
@@ -156,6 +167,9 @@ func (r *cloudDatabaseClusterDatabaseResource) Create(ctx context.Context, req r
 }
 
 func (r *cloudDatabaseClusterDatabaseResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_cloud_database_cluster_database.Read")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var plan cloudDatabaseClusterDatabaseResource
 	diags := req.State.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -208,6 +222,9 @@ func (r *cloudDatabaseClusterDatabaseResource) Read(ctx context.Context, req res
 }
 
 func (r *cloudDatabaseClusterDatabaseResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_cloud_database_cluster_database.Update")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var plan cloudDatabaseClusterDatabaseResource
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -226,7 +243,7 @@ func (r *cloudDatabaseClusterDatabaseResource) Update(ctx context.Context, req r
 	defer cancel()
 
 	client := r.nexaaClient.API
-	err := waitForUnlocked(ctx, cloudDatabaseClusterLocked(), client, plan.Cluster.Namespace.ValueString(), plan.Cluster.Name.ValueString())
+	err := waitForUnlocked(ctx, cloudDatabaseClusterLocked(), client, r.nexaaClient.PollInterval, plan.Cluster.Namespace.ValueString(), plan.Cluster.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating database", "Cloud database cluster is not ready yet: "+err.Error())
 		return
@@ -266,6 +283,9 @@ func (r *cloudDatabaseClusterDatabaseResource) Update(ctx context.Context, req r
 }
 
 func (r *cloudDatabaseClusterDatabaseResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_cloud_database_cluster_database.Delete")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var plan cloudDatabaseClusterDatabaseResource
 	diags := req.State.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -285,7 +305,7 @@ func (r *cloudDatabaseClusterDatabaseResource) Delete(ctx context.Context, req r
 	defer cancel()
 
 	client := r.nexaaClient.API
-	err := waitForUnlocked(ctx, cloudDatabaseClusterLocked(), client, plan.Cluster.Namespace.ValueString(), plan.Cluster.Name.ValueString())
+	err := waitForUnlocked(ctx, cloudDatabaseClusterLocked(), client, r.nexaaClient.PollInterval, plan.Cluster.Namespace.ValueString(), plan.Cluster.Name.ValueString())
 
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting database", "Cloud database cluster is not ready yet: "+err.Error())
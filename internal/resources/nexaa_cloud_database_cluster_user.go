@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -20,12 +21,14 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/nexaa-cloud/nexaa-cli/api"
 	nexaaclient "github.com/nexaa-cloud/terraform-provider-nexaa/internal/client"
+	"github.com/nexaa-cloud/terraform-provider-nexaa/internal/tracing"
 )
 
 var (
 	_ resource.Resource                = &cloudDatabaseClusterUserResource{}
 	_ resource.ResourceWithImportState = &cloudDatabaseClusterUserResource{}
 	_ resource.ResourceWithConfigure   = &cloudDatabaseClusterUserResource{}
+	_ resource.ResourceWithModifyPlan  = &cloudDatabaseClusterUserResource{}
 )
 
 func NewDatabaseUserResource() resource.Resource {
@@ -59,6 +62,11 @@ func (r *cloudDatabaseClusterUserResource) Metadata(_ context.Context, req resou
 	resp.TypeName = req.ProviderTypeName + "_cloud_database_cluster_user"
 }
 
+// ModifyPlan defers the resource while the namespace of its cluster is unknown.
+func (r *cloudDatabaseClusterUserResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	deferUnknownNamespace(ctx, req, resp, path.Root("cluster").AtName("namespace"))
+}
+
 func (r *cloudDatabaseClusterUserResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Database User resource representing a database user within a cloud database cluster on Nexaa.",
@@ -127,6 +135,9 @@ func (r *cloudDatabaseClusterUserResource) Schema(_ context.Context, _ resource.
 }
 
 func (r *cloudDatabaseClusterUserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_cloud_database_cluster_user.Create")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var plan cloudDatabaseClusterUserResource
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -146,7 +157,7 @@ func (r *cloudDatabaseClusterUserResource) Create(ctx context.Context, req resou
 	defer cancel()
 
 	client := r.nexaaClient.API
-	err := waitForUnlocked(ctx, cloudDatabaseClusterLocked(), client, plan.Cluster.Namespace.ValueString(), plan.Cluster.Name.ValueString())
+	err := waitForUnlocked(ctx, cloudDatabaseClusterLocked(), client, r.nexaaClient.PollInterval, plan.Cluster.Namespace.ValueString(), plan.Cluster.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating user", "Could not reach a unlocked state: "+err.Error())
 		return
@@ -185,6 +196,9 @@ func (r *cloudDatabaseClusterUserResource) Create(ctx context.Context, req resou
 }
 
 func (r *cloudDatabaseClusterUserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_cloud_database_cluster_user.Read")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var plan cloudDatabaseClusterUserResource
 	diags := req.State.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -234,6 +248,9 @@ func (r *cloudDatabaseClusterUserResource) Read(ctx context.Context, req resourc
 }
 
 func (r *cloudDatabaseClusterUserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_cloud_database_cluster_user.Update")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var plan cloudDatabaseClusterUserResource
 	var state cloudDatabaseClusterUserResource
 
@@ -255,7 +272,7 @@ func (r *cloudDatabaseClusterUserResource) Update(ctx context.Context, req resou
 	defer cancel()
 
 	client := r.nexaaClient.API
-	err := waitForUnlocked(ctx, cloudDatabaseClusterLocked(), client, plan.Cluster.Namespace.ValueString(), plan.Cluster.Name.ValueString())
+	err := waitForUnlocked(ctx, cloudDatabaseClusterLocked(), client, r.nexaaClient.PollInterval, plan.Cluster.Namespace.ValueString(), plan.Cluster.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating user", "Could not reach a unlocked state: "+err.Error())
 		return
@@ -296,6 +313,9 @@ func (r *cloudDatabaseClusterUserResource) Update(ctx context.Context, req resou
 }
 
 func (r *cloudDatabaseClusterUserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_cloud_database_cluster_user.Delete")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var plan cloudDatabaseClusterUserResource
 	diags := req.State.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -315,7 +335,7 @@ func (r *cloudDatabaseClusterUserResource) Delete(ctx context.Context, req resou
 	defer cancel()
 
 	client := r.nexaaClient.API
-	err := waitForUnlocked(ctx, cloudDatabaseClusterLocked(), client, plan.Cluster.Namespace.ValueString(), plan.Cluster.Name.ValueString())
+	err := waitForUnlocked(ctx, cloudDatabaseClusterLocked(), client, r.nexaaClient.PollInterval, plan.Cluster.Namespace.ValueString(), plan.Cluster.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting user", "Could not reach a unlocked state: "+err.Error())
 		return
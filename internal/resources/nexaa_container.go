@@ -6,7 +6,6 @@ package resources
 import (
 	"context"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
@@ -16,27 +15,38 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 
 	nexaaclient "github.com/nexaa-cloud/terraform-provider-nexaa/internal/client"
+	"github.com/nexaa-cloud/terraform-provider-nexaa/internal/enums"
+	"github.com/nexaa-cloud/terraform-provider-nexaa/internal/tracing"
 
 	"github.com/nexaa-cloud/nexaa-cli/api"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &containerResource{}
-	_ resource.ResourceWithImportState = &containerResource{}
-	_ resource.ResourceWithIdentity    = &containerResource{}
-	_ resource.ResourceWithConfigure   = &containerResource{}
+	_ resource.Resource                 = &containerResource{}
+	_ resource.ResourceWithImportState  = &containerResource{}
+	_ resource.ResourceWithIdentity     = &containerResource{}
+	_ resource.ResourceWithConfigure    = &containerResource{}
+	_ resource.ResourceWithModifyPlan   = &containerResource{}
+	_ resource.ResourceWithUpgradeState = &containerResource{}
+	_ resource.ResourceWithMoveState    = &containerResource{}
 )
 
 // NewContainerResource is a helper function to simplify the provider implementation.
@@ -65,23 +75,33 @@ type containerResource struct {
 	Namespace            types.String   `tfsdk:"namespace"`
 	Image                types.String   `tfsdk:"image"`
 	Registry             types.String   `tfsdk:"registry"`
-	Resources            types.String   `tfsdk:"resources"`
+	Resources            types.Object   `tfsdk:"resources"`
 	Command              types.List     `tfsdk:"command"`
 	Entrypoint           types.List     `tfsdk:"entrypoint"`
 	EnvironmentVariables types.Set      `tfsdk:"environment_variables"`
+	SecretEnvWO          types.Map      `tfsdk:"secret_environment_variables_wo"`
+	SecretEnvWOVersion   types.Int64    `tfsdk:"secret_environment_variables_wo_version"`
+	EnvFrom              types.List     `tfsdk:"env_from"`
 	Ports                types.List     `tfsdk:"ports"`
 	Ingresses            types.List     `tfsdk:"ingresses"`
 	ExternalConnection   types.Object   `tfsdk:"external_connection"`
 	Mounts               types.List     `tfsdk:"mounts"`
 	HealthCheck          types.Object   `tfsdk:"health_check"`
 	Scaling              types.Object   `tfsdk:"scaling"`
+	Replicas             types.Int64    `tfsdk:"replicas"`
 	Status               types.String   `tfsdk:"status"`
+	CurrentReplicas      types.Int64    `tfsdk:"current_replicas"`
+	WaitForReady         types.Bool     `tfsdk:"wait_for_ready"`
+	RedeployTrigger      types.Map      `tfsdk:"redeploy_trigger"`
+	RollbackOnFailure    types.Bool     `tfsdk:"rollback_on_failure"`
 	Timeouts             timeouts.Value `tfsdk:"timeouts"`
 }
 
 type mountResource struct {
-	Path   types.String `tfsdk:"path"`
-	Volume types.String `tfsdk:"volume"`
+	Path       types.String `tfsdk:"path"`
+	Volume     types.String `tfsdk:"volume"`
+	AutoCreate types.Bool   `tfsdk:"auto_create"`
+	Size       types.Int64  `tfsdk:"size"`
 }
 
 type environmentVariableResource struct {
@@ -94,7 +114,7 @@ type ingresResource struct {
 	DomainName types.String `tfsdk:"domain_name"`
 	Port       types.Int64  `tfsdk:"port"`
 	TLS        types.Bool   `tfsdk:"tls"`
-	AllowList  types.List   `tfsdk:"allowlist"`
+	AllowList  types.Set    `tfsdk:"allowlist"`
 }
 
 type containerExternalConnectionResource struct {
@@ -137,6 +157,41 @@ func (r *containerResource) Metadata(_ context.Context, req resource.MetadataReq
 	resp.TypeName = req.ProviderTypeName + "_container"
 }
 
+// ModifyPlan fills in the namespace from the provider's default_namespace when it is omitted,
+// validates the scaling block and leaves replicas unknown when the update can change it.
+func (r *containerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	applyDefaultNamespace(ctx, r.nexaaClient, req, resp)
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var scaling types.Object
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("scaling"), &scaling)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(validateScalingPlan(ctx, scaling)...)
+	if resp.Diagnostics.HasError() || req.State.Raw.IsNull() || resp.Plan.Raw.Equal(req.State.Raw) {
+		return
+	}
+
+	var stateScaling types.Object
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("scaling"), &stateScaling)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if scalingChangesReplicas(scaling, stateScaling) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("replicas"), types.Int64Unknown())...)
+	}
+}
+
+// UpgradeState converts state written before resources became a cpu/ram object.
+func (r *containerResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {StateUpgrader: upgradeContainerStateV0},
+	}
+}
+
 func (r *containerResource) IdentitySchema(ctx context.Context, request resource.IdentitySchemaRequest, response *resource.IdentitySchemaResponse) {
 	response.IdentitySchema = identityschema.Schema{
 		Attributes: map[string]identityschema.Attribute{
@@ -154,6 +209,7 @@ func (r *containerResource) IdentitySchema(ctx context.Context, request resource
 
 func (r *containerResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     1,
 		Description: "Container resource representing a container that will be deployed on nexaa.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -171,8 +227,9 @@ func (r *containerResource) Schema(ctx context.Context, _ resource.SchemaRequest
 				},
 			},
 			"namespace": schema.StringAttribute{
-				Required:    true,
-				Description: "Name of the namespace that the container will belong to",
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of the namespace that the container will belong to, defaults to the default_namespace of the provider",
 			},
 			"image": schema.StringAttribute{
 				Required:    true,
@@ -182,9 +239,28 @@ func (r *containerResource) Schema(ctx context.Context, _ resource.SchemaRequest
 				Optional:    true,
 				Description: "The name of the registry used to access images that are saved in a private environment, leave empty to use a public registry",
 			},
-			"resources": schema.StringAttribute{
+			"resources": schema.SingleNestedAttribute{
+				Attributes: map[string]schema.Attribute{
+					"cpu": schema.Float64Attribute{
+						Required:    true,
+						Description: "The amount of cpu used for the container, can be the following values: 0.25, 0.5, 0.75, 1, 2, 3, 4",
+						Validators: []validator.Float64{
+							float64validator.OneOf(enums.CPU...),
+						},
+					},
+					"ram": schema.Float64Attribute{
+						Required:    true,
+						Description: "The amount of memory used for the container (in GB), can be the following values: 0.5, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16",
+						Validators: []validator.Float64{
+							float64validator.OneOf(enums.RAM...),
+						},
+					},
+				},
 				Required:    true,
-				Description: "The resources used for running the container, this can be specified via the nexaa_container_resources data source, with specifying the amount of cpu and memory",
+				Description: "The resources used for running the container, only specific combinations of cpu and memory are available",
+				Validators: []validator.Object{
+					containerResourcesValidator{},
+				},
 			},
 			"command": schema.ListAttribute{
 				ElementType: types.StringType,
@@ -201,6 +277,9 @@ func (r *containerResource) Schema(ctx context.Context, _ resource.SchemaRequest
 				Optional:    true,
 				Computed:    true,
 				Description: "The ports used to expose for traffic, format as from:to",
+				Validators: []validator.List{
+					portMappingsValidator{},
+				},
 			},
 			"environment_variables": schema.SetNestedAttribute{
 				NestedObject: schema.NestedAttributeObject{
@@ -228,6 +307,33 @@ func (r *containerResource) Schema(ctx context.Context, _ resource.SchemaRequest
 				Computed:    true,
 				Description: "Environment variables used in the container; order is not significant and matched by name",
 			},
+			"secret_environment_variables_wo": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				WriteOnly:   true,
+				Sensitive:   true,
+				Description: "Secret environment variables whose values are never stored in the Terraform state or plan, keyed by name. The values are only sent when secret_environment_variables_wo_version changes. Requires Terraform 1.11 or later.",
+			},
+			"secret_environment_variables_wo_version": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Version of secret_environment_variables_wo. Change it to send new values of the write-only environment variables to the API.",
+			},
+			"env_from": schema.ListNestedAttribute{
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"cloud_database_cluster_user": schema.StringAttribute{
+							Required:    true,
+							Description: "The id of the nexaa_cloud_database_cluster_user whose connection details are injected",
+						},
+						"prefix": schema.StringAttribute{
+							Optional:    true,
+							Description: "Prefix of the injected HOST, PORT, USER and PASSWORD variables, defaults to DATABASE_",
+						},
+					},
+				},
+				Optional:    true,
+				Description: "Injects the connection details of a cloud database cluster user as environment variables. The password is read from the credentials of the user and injected as a secret variable. The injected variables are not part of environment_variables",
+			},
 			"ingresses": schema.ListNestedAttribute{
 				Validators: []validator.List{
 					noDuplicateDefaultIngressValidator{},
@@ -237,7 +343,7 @@ func (r *containerResource) Schema(ctx context.Context, _ resource.SchemaRequest
 						"domain_name": schema.StringAttribute{
 							Optional:    true,
 							Computed:    true,
-							Description: "The domain used for the ingress, defaults to https://{tenant}-{namespaceName}-{containerName}.container.tilaa.cloud",
+							Description: "The domain used for the ingress, defaults to https://{tenant}-{namespaceName}-{containerName}.container.tilaa.cloud. The configured spelling is kept in state when the API normalizes it",
 							PlanModifiers: []planmodifier.String{
 								stringplanmodifier.UseStateForUnknown(),
 							},
@@ -250,22 +356,23 @@ func (r *containerResource) Schema(ctx context.Context, _ resource.SchemaRequest
 							Required:    true,
 							Description: "Boolean representing if you want TLS enabled or not",
 						},
-						"allowlist": schema.ListAttribute{
+						"allowlist": schema.SetAttribute{
 							ElementType: types.StringType,
 							Optional:    true,
 							Computed:    true,
-							Description: "A list with the IP's that can access the ingress url, 0.0.0.0/0 to make it accessible for everyone, can be in ipv4 and/or ipv6 format.",
-							Default: listdefault.StaticValue(
-								types.ListValueMust(types.StringType, []attr.Value{
+							Description: "A set with the IP's or CIDR ranges that can access the ingress url, 0.0.0.0/0 to make it accessible for everyone, can be in ipv4 and/or ipv6 format.",
+							Default: setdefault.StaticValue(
+								types.SetValueMust(types.StringType, []attr.Value{
 									types.StringValue("0.0.0.0/0"),
 									types.StringValue("::/0"),
 								}),
 							),
-							PlanModifiers: []planmodifier.List{
-								listplanmodifier.UseStateForUnknown(),
+							PlanModifiers: []planmodifier.Set{
+								setplanmodifier.UseStateForUnknown(),
 							},
-							Validators: []validator.List{
+							Validators: []validator.Set{
 								noEmptyAllowlistValidator{},
+								setvalidator.ValueStringsAre(cidrValidator{}),
 							},
 						},
 					},
@@ -340,6 +447,16 @@ func (r *containerResource) Schema(ctx context.Context, _ resource.SchemaRequest
 							Required:    true,
 							Description: "The name of the volume that is used for the mount",
 						},
+						"auto_create": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(false),
+							Description: "Create the volume when it does not exist yet. The created volume is not managed by Terraform and is kept when the mount is removed",
+						},
+						"size": schema.Int64Attribute{
+							Optional:    true,
+							Description: "Size of the volume in GB, min 1GB/ max 100GB. Used when the volume is auto created, a larger size increases an existing volume",
+						},
 					},
 				},
 				Computed:    true,
@@ -394,7 +511,7 @@ func (r *containerResource) Schema(ctx context.Context, _ resource.SchemaRequest
 							},
 							"triggers": schema.ListNestedAttribute{
 								Optional:    true,
-								Description: "Used as condition as to when the container needs to add a replica, you can have 2 triggers, one for each type",
+								Description: "Used as condition as to when the container needs to add a replica, you can have at most 2 triggers, one for each type",
 								NestedObject: schema.NestedAttributeObject{
 									Attributes: map[string]schema.Attribute{
 										"type": schema.StringAttribute{
@@ -406,15 +523,29 @@ func (r *containerResource) Schema(ctx context.Context, _ resource.SchemaRequest
 										},
 										"threshold": schema.Int64Attribute{
 											Required:    true,
-											Description: "The amount percentage wise needed to add another replica",
+											Description: "The amount percentage wise needed to add another replica, between 1 and 100",
+											Validators: []validator.Int64{
+												int64validator.Between(1, 100),
+											},
 										},
 									},
 								},
+								Validators: []validator.List{
+									listvalidator.SizeAtMost(2),
+									uniqueTriggerTypesValidator{},
+								},
 							},
 						},
 					},
 				},
 			},
+			"replicas": schema.Int64Attribute{
+				Computed:    true,
+				Description: "The number of replicas the API runs the container with. Unlike `scaling.manual_input`, which is the configured count, this is read back from the API and also set when the container autoscales, where it is the count chosen between `minimal_replicas` and `maximal_replicas`",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
 			"status": schema.StringAttribute{
 				Description: "The status of the container",
 				Computed:    true,
@@ -422,10 +553,32 @@ func (r *containerResource) Schema(ctx context.Context, _ resource.SchemaRequest
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"current_replicas": schema.Int64Attribute{
+				Description: "The number of replicas of the container that are available, as last reported by the API. Unlike the configured scaling this follows the actual rollout, so it can be lower while the container is starting or failing",
+				Computed:    true,
+			},
+			"wait_for_ready": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Wait on create and update until the container is unlocked and all its replicas are available, failing with the last state of the container when it does not get there in time. The wait is bounded by the create and update timeouts, so raise those when enabling this",
+			},
+			"rollback_on_failure": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Restore the previous configuration when the container does not become ready after an update. Implies wait_for_ready on update. Write-only and env_from variables are not restored",
+			},
+			"redeploy_trigger": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Arbitrary values that redeploy the container when they change, for example to pull a mutable tag like latest again. The API has no restart operation, so a change sends the configuration of the container again in an in-place update",
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"timeouts": timeouts.Block(ctx, timeouts.Opts{
 				Create: true,
+				Read:   true,
 				Update: true,
 				Delete: true,
 			}),
@@ -435,6 +588,9 @@ func (r *containerResource) Schema(ctx context.Context, _ resource.SchemaRequest
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *containerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_container.Create")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var plan containerResource
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -442,7 +598,9 @@ func (r *containerResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	createTimeout, diags := plan.Timeouts.Create(ctx, 30*time.Second)
+	// The create timeout also covers waiting for the lock the API holds while
+	// it sets up the container, which can take minutes.
+	createTimeout, diags := plan.Timeouts.Create(ctx, 5*time.Minute)
 
 	resp.Diagnostics.Append(diags...)
 
@@ -457,13 +615,19 @@ func (r *containerResource) Create(ctx context.Context, req resource.CreateReque
 		plan.Registry = types.StringNull()
 	}
 
+	containerResources, diags := buildContainerResourcesInput(ctx, plan.Resources)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Build input struct
 	input := api.ContainerCreateInput{
 		Namespace: plan.Namespace.ValueString(),
 		Name:      plan.Name.ValueString(),
 		Image:     plan.Image.ValueString(),
 		Registry:  plan.Registry.ValueStringPointer(),
-		Resources: api.ContainerResources(plan.Resources.ValueString()),
+		Resources: containerResources,
 		Type:      api.ContainerTypeDefault,
 	}
 
@@ -513,15 +677,51 @@ func (r *containerResource) Create(ctx context.Context, req resource.CreateReque
 	}
 	input.ExternalConnection = externalConnInput
 
+	// Refuse to take over a container that already exists
+	r.nexaaClient.Lock("container:" + plan.Namespace.ValueString() + "/" + plan.Name.ValueString())
+	defer r.nexaaClient.Unlock("container:" + plan.Namespace.ValueString() + "/" + plan.Name.ValueString())
+
+	client := r.nexaaClient.API
+	if _, checkErr := client.ListContainerByName(plan.Namespace.ValueString(), plan.Name.ValueString()); checkErr == nil {
+		resp.Diagnostics.AddError("Container already exists",
+			"A container named "+plan.Name.ValueString()+" already exists in namespace "+plan.Namespace.ValueString()+". "+
+				"To manage it with Terraform use: terraform import nexaa_container.example "+plan.Namespace.ValueString()+"/"+plan.Name.ValueString())
+		return
+	} else if !isNotFoundErr(checkErr) {
+		resp.Diagnostics.AddError("Error checking for existing container",
+			"Could not verify name availability: "+checkErr.Error())
+		return
+	}
+
 	// Environment variables (build API input from plan)
 	inputs, dEnv := extractEnvInputsFromSet(ctx, plan.EnvironmentVariables)
 	resp.Diagnostics.Append(dEnv...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	secretHashes := recordSecretEnvHashes(newSecretEnvHashes(), inputs)
+	writeOnlyEnv, dEnv := readWriteOnlyEnv(ctx, req.Config)
+	resp.Diagnostics.Append(dEnv...)
+	writeOnlyInputs, dEnv := writeOnlyEnvInputs(writeOnlyEnv, inputs)
+	resp.Diagnostics.Append(dEnv...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	inputs = append(inputs, writeOnlyInputs...)
+	envFromInputs, dEnv := buildEnvFromInputs(ctx, r.nexaaClient.API, plan.EnvFrom, inputs)
+	resp.Diagnostics.Append(dEnv...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	inputs = append(inputs, envFromInputs...)
 	if len(inputs) > 0 {
 		input.EnvironmentVariables = inputs
 	}
+	writeOnlyNames := map[string]bool{}
+	for name := range writeOnlyEnv {
+		writeOnlyNames[name] = true
+	}
+	injectedNames := envFromNames(ctx, plan.EnvFrom)
 
 	// Health check
 	healthCheck, diags := buildHealthCheckInput(ctx, plan.HealthCheck)
@@ -532,96 +732,47 @@ func (r *containerResource) Create(ctx context.Context, req resource.CreateReque
 	input.HealthCheck = healthCheck
 
 	// Scaling
-	var scaling scalingResource
-	diags = plan.Scaling.As(ctx, &scaling, basetypes.ObjectAsOptions{})
+	scalingInput, diags := buildScalingInput(ctx, plan.Scaling)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-
-	// Validate scaling configuration
-	if err := validateScalingConfig(scaling); err != nil {
-		resp.Diagnostics.AddError("Invalid scaling configuration", err.Error())
-		return
-	}
-
-	switch scaling.Type.ValueString() {
-	case "auto":
-		if !scaling.AutoInput.IsNull() && !scaling.AutoInput.IsUnknown() {
-			var autoInput autoscaleResource
-			diags := scaling.AutoInput.As(ctx, &autoInput, basetypes.ObjectAsOptions{})
-			resp.Diagnostics.Append(diags...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
-
-			var triggers []triggerResource
-			diags = autoInput.Triggers.ElementsAs(ctx, &triggers, false)
-			resp.Diagnostics.Append(diags...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
-
-			auto := api.AutoScalingInput{
-				Replicas: api.ReplicasInput{
-					Minimum: int(autoInput.MinimalReplicas.ValueInt64()),
-					Maximum: int(autoInput.MaximalReplicas.ValueInt64()),
-				},
-			}
-
-			for _, t := range triggers {
-				auto.Triggers = append(auto.Triggers, api.AutoScalingTriggerInput{
-					Type:      api.AutoScalingType(t.Type.ValueString()),
-					Threshold: int(t.Threshold.ValueInt64()),
-				})
-			}
-
-			input.Scaling = &api.ScalingInput{Auto: &auto}
-		}
-
-	case "manual":
-		if !scaling.Manualinput.IsNull() && !scaling.Manualinput.IsUnknown() {
-			replicas := int(scaling.Manualinput.ValueInt64())
-			input.Scaling = &api.ScalingInput{
-				Manual: &api.ManualScalingInput{
-					Replicas: replicas,
-				},
-			}
-		}
-	}
+	input.Scaling = scalingInput
 
 	// Create containerResult
-	r.nexaaClient.Lock("container:" + plan.Namespace.ValueString() + "/" + plan.Name.ValueString())
-	defer r.nexaaClient.Unlock("container:" + plan.Namespace.ValueString() + "/" + plan.Name.ValueString())
-
-	client := r.nexaaClient.API
-	if _, checkErr := client.ListContainerByName(plan.Namespace.ValueString(), plan.Name.ValueString()); checkErr == nil {
-		resp.Diagnostics.AddError("Container already exists",
-			"A container named "+plan.Name.ValueString()+" already exists in namespace "+plan.Namespace.ValueString()+". "+
-				"To manage it with Terraform use: terraform import nexaa_container.example "+plan.Namespace.ValueString()+"/"+plan.Name.ValueString())
-		return
-	} else if !isNotFoundErr(checkErr) {
-		resp.Diagnostics.AddError("Error checking for existing container",
-			"Could not verify name availability: "+checkErr.Error())
-		return
-	}
-
 	containerResult, err := client.ContainerCreate(input)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating container", "Could not create container: "+err.Error())
 		return
 	}
 
+	// Wait for the API to finish setting up the container so that data sources
+	// and resources reading it right after see its final state. The container
+	// exists from here on, so a failed wait still saves it in state.
+	err = waitForUnlocked(ctx, containerLocked(), client, r.nexaaClient.PollInterval, plan.Namespace.ValueString(), containerResult.Name)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating container", "Container was created but is still locked: "+err.Error())
+	} else if created, err := client.ListContainerByName(plan.Namespace.ValueString(), containerResult.Name); err == nil {
+		containerResult = created
+	} else {
+		resp.Diagnostics.AddWarning("Could not refresh container", "The container was created, but reading it back failed: "+err.Error())
+	}
+
 	// Set all fields in plan from returned containerResult
 	plan.ID = types.StringValue(containerResult.Name)
 	plan.Namespace = types.StringValue(plan.Namespace.ValueString())
 	plan.Name = types.StringValue(containerResult.Name)
 	plan.Image = types.StringValue(containerResult.Image)
 	plan.Status = types.StringValue(containerResult.State)
+	plan.CurrentReplicas = types.Int64Value(int64(containerResult.AvailableReplicas))
 
 	plan.Registry = processRegistryName(containerResult)
 
-	plan.Resources = types.StringValue(string(containerResult.Resources))
+	plan.Resources, diags = buildContainerResourcesFromApi(containerResult.Resources)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Command
 	plan.Command, diags = buildCommandState(containerResult.Command)
@@ -639,7 +790,8 @@ func (r *containerResource) Create(ctx context.Context, req resource.CreateReque
 
 	// Environment variables (state population)
 	if containerResult.EnvironmentVariables != nil {
-		setVal, d := buildEnvSetFromAPI(ctx, containerResult.EnvironmentVariables, input.EnvironmentVariables, types.SetNull(envVarObjectType()), secretUseProvided)
+		apiVars := withoutEnvNames(withoutEnvNames(containerResult.EnvironmentVariables, writeOnlyNames), injectedNames)
+		setVal, d := buildEnvSetFromAPI(ctx, apiVars, input.EnvironmentVariables, types.SetNull(envVarObjectType()), secretUseProvided)
 		resp.Diagnostics.Append(d...)
 		if resp.Diagnostics.HasError() {
 			return
@@ -657,7 +809,7 @@ func (r *containerResource) Create(ctx context.Context, req resource.CreateReque
 
 	// Mounts
 	if containerResult.Mounts != nil {
-		mountList, d := buildMountsFromApi(containerResult.Mounts)
+		mountList, d := buildMountsFromApi(ctx, containerResult.Mounts, plan.Mounts)
 		resp.Diagnostics.Append(d...)
 		if resp.Diagnostics.HasError() {
 			return
@@ -685,95 +837,28 @@ func (r *containerResource) Create(ctx context.Context, req resource.CreateReque
 	plan.HealthCheck = buildHealthCheckState(containerResult)
 
 	// Scaling
-	autoInputType := types.ObjectType{
-		AttrTypes: map[string]attr.Type{
-			"minimal_replicas": types.Int64Type,
-			"maximal_replicas": types.Int64Type,
-			"triggers": types.ListType{
-				ElemType: types.ObjectType{
-					AttrTypes: map[string]attr.Type{
-						"type":      types.StringType,
-						"threshold": types.Int64Type,
-					},
-				},
-			},
-		},
-	}
-
-	scalingType := types.ObjectType{
-		AttrTypes: map[string]attr.Type{
-			"type":         types.StringType,
-			"manual_input": types.Int64Type,
-			"auto_input":   autoInputType,
-		},
+	plan.Scaling, diags = buildScalingFromApi(containerResult)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
+	plan.Replicas = types.Int64Value(int64(containerResult.NumberOfReplicas))
 
-	var scalingObj attr.Value = types.ObjectNull(scalingType.AttrTypes)
-
-	if containerResult.AutoScaling != nil {
-		var triggerVals []attr.Value
-		for _, t := range containerResult.AutoScaling.Triggers {
-			triggerObj := types.ObjectValueMust(
-				map[string]attr.Type{
-					"type":      types.StringType,
-					"threshold": types.Int64Type,
-				},
-				map[string]attr.Value{
-					"type":      types.StringValue(strings.ToUpper(t.Type)),
-					"threshold": types.Int64Value(int64(t.Threshold)),
-				},
-			)
-			triggerVals = append(triggerVals, triggerObj)
+	// The container exists from here on, so it is saved in state even when it
+	// does not become ready; Terraform then marks it tainted.
+	if plan.WaitForReady.ValueBool() {
+		state, err := waitForContainerReady(ctx, client, r.nexaaClient.PollInterval, plan.Namespace.ValueString(), plan.Name.ValueString())
+		if state != "" {
+			plan.Status = types.StringValue(state)
 		}
-
-		triggersList, diags := types.ListValue(
-			types.ObjectType{
-				AttrTypes: map[string]attr.Type{
-					"type":      types.StringType,
-					"threshold": types.Int64Type,
-				},
-			}, triggerVals)
-		if diags.HasError() {
-			resp.Diagnostics.AddError("Scaling triggers error", "Failed to build scaling triggers list")
-			return
+		if err != nil {
+			resp.Diagnostics.AddError("Container is not ready", err.Error())
 		}
-
-		autoInput := types.ObjectValueMust(
-			autoInputType.AttrTypes,
-			map[string]attr.Value{
-				"minimal_replicas": types.Int64Value(int64(containerResult.AutoScaling.Replicas.Minimum)),
-				"maximal_replicas": types.Int64Value(int64(containerResult.AutoScaling.Replicas.Maximum)),
-				"triggers":         triggersList,
-			},
-		)
-
-		scalingObj = types.ObjectValueMust(
-			scalingType.AttrTypes,
-			map[string]attr.Value{
-				"type":         types.StringValue("auto"),
-				"manual_input": types.Int64Null(),
-				"auto_input":   autoInput,
-			},
-		)
-	} else if containerResult.NumberOfReplicas >= 0 {
-		scalingObj = types.ObjectValueMust(
-			scalingType.AttrTypes,
-			map[string]attr.Value{
-				"type":         types.StringValue("manual"),
-				"manual_input": types.Int64Value(int64(containerResult.NumberOfReplicas)),
-				"auto_input":   types.ObjectNull(autoInputType.AttrTypes),
-			},
-		)
-	}
-
-	if obj, ok := scalingObj.(types.Object); ok {
-		plan.Scaling = obj
-	} else {
-		resp.Diagnostics.AddError("Error creating containerResult", "Could not transform scaling object")
-		return
 	}
 
 	// Set state
+	resp.Diagnostics.Append(storeWriteOnlyEnvNames(ctx, resp.Private, writeOnlyNames)...)
+	resp.Diagnostics.Append(storeSecretEnvHashes(ctx, resp.Private, secretHashes)...)
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -793,6 +878,9 @@ func (r *containerResource) Create(ctx context.Context, req resource.CreateReque
 
 // Read refreshes the Terraform state with the latest data.
 func (r *containerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_container.Read")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var state containerResource
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -800,9 +888,20 @@ func (r *containerResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, 30*time.Second)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// Fetch the created container
 	client := r.nexaaClient.API
-	container, err := client.ListContainerByName(state.Namespace.ValueString(), state.Name.ValueString())
+	container, err := callWithContext(ctx, func() (api.ContainerResult, error) {
+		return client.ListContainerByName(state.Namespace.ValueString(), state.Name.ValueString())
+	})
 	if err != nil {
 		if isNotFoundErr(err) {
 			resp.State.RemoveResource(ctx)
@@ -820,7 +919,11 @@ func (r *containerResource) Read(ctx context.Context, req resource.ReadRequest,
 
 	state.Registry = processRegistryName(container)
 
-	state.Resources = types.StringValue(string(container.Resources))
+	state.Resources, diags = buildContainerResourcesFromApi(container.Resources)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Command
 	state.Command, diags = buildCommandState(container.Command)
@@ -837,8 +940,21 @@ func (r *containerResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	// Environment variables (refresh state)
+	writeOnlyNames, diags := writeOnlyEnvNames(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	if container.EnvironmentVariables != nil {
-		setVal, d := buildEnvSetFromAPI(ctx, container.EnvironmentVariables, nil, state.EnvironmentVariables, secretPreservePrev)
+		apiVars := withoutEnvNames(withoutEnvNames(container.EnvironmentVariables, writeOnlyNames), envFromNames(ctx, state.EnvFrom))
+		setVal, d := buildEnvSetFromAPI(ctx, apiVars, nil, state.EnvironmentVariables, secretPreservePrev)
+		resp.Diagnostics.Append(d...)
+		secretHashes, d := readSecretEnvHashes(ctx, req.Private)
+		resp.Diagnostics.Append(d...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		setVal, d = maskStaleSecrets(ctx, setVal, secretHashes)
 		resp.Diagnostics.Append(d...)
 		if resp.Diagnostics.HasError() {
 			return
@@ -855,7 +971,7 @@ func (r *containerResource) Read(ctx context.Context, req resource.ReadRequest,
 	state.Ports = portList
 	// Mounts
 	if container.Mounts != nil {
-		mountList, d := buildMountsFromApi(container.Mounts)
+		mountList, d := buildMountsFromApi(ctx, container.Mounts, state.Mounts)
 		resp.Diagnostics.Append(d...)
 		if resp.Diagnostics.HasError() {
 			return
@@ -883,97 +999,21 @@ func (r *containerResource) Read(ctx context.Context, req resource.ReadRequest,
 	state.HealthCheck = buildHealthCheckState(container)
 
 	// Scaling
-	// Declare autoInputType once
-	autoInputType := types.ObjectType{
-		AttrTypes: map[string]attr.Type{
-			"minimal_replicas": types.Int64Type,
-			"maximal_replicas": types.Int64Type,
-			"triggers": types.ListType{
-				ElemType: types.ObjectType{
-					AttrTypes: map[string]attr.Type{
-						"type":      types.StringType,
-						"threshold": types.Int64Type,
-					},
-				},
-			},
-		},
-	}
-
-	// Declare outer scaling type
-	scalingType := types.ObjectType{
-		AttrTypes: map[string]attr.Type{
-			"type":         types.StringType,
-			"manual_input": types.Int64Type,
-			"auto_input":   autoInputType,
-		},
-	}
-
-	// Initialize scalingObj to null (failsafe default)
-	var scalingObj attr.Value = types.ObjectNull(scalingType.AttrTypes)
-
-	if container.AutoScaling != nil {
-		var triggerVals []attr.Value
-		for _, t := range container.AutoScaling.Triggers {
-			triggerObj := types.ObjectValueMust(
-				map[string]attr.Type{
-					"type":      types.StringType,
-					"threshold": types.Int64Type,
-				},
-				map[string]attr.Value{
-					"type":      types.StringValue(strings.ToUpper(t.Type)),
-					"threshold": types.Int64Value(int64(t.Threshold)),
-				},
-			)
-			triggerVals = append(triggerVals, triggerObj)
-		}
-
-		triggersList, diags := types.ListValue(
-			types.ObjectType{
-				AttrTypes: map[string]attr.Type{
-					"type":      types.StringType,
-					"threshold": types.Int64Type,
-				},
-			}, triggerVals)
-		if diags.HasError() {
-			return
-		}
-
-		autoInput := types.ObjectValueMust(
-			autoInputType.AttrTypes,
-			map[string]attr.Value{
-				"minimal_replicas": types.Int64Value(int64(container.AutoScaling.Replicas.Minimum)),
-				"maximal_replicas": types.Int64Value(int64(container.AutoScaling.Replicas.Maximum)),
-				"triggers":         triggersList,
-			},
-		)
-
-		scalingObj = types.ObjectValueMust(
-			scalingType.AttrTypes,
-			map[string]attr.Value{
-				"type":         types.StringValue("auto"),
-				"manual_input": types.Int64Null(),
-				"auto_input":   autoInput,
-			},
-		)
-	} else if container.NumberOfReplicas >= 0 {
-		scalingObj = types.ObjectValueMust(
-			scalingType.AttrTypes,
-			map[string]attr.Value{
-				"type":         types.StringValue("manual"),
-				"manual_input": types.Int64Value(int64(container.NumberOfReplicas)),
-				"auto_input":   types.ObjectNull(autoInputType.AttrTypes),
-			},
-		)
-	}
-
-	if obj, ok := scalingObj.(types.Object); ok {
-		state.Scaling = obj
-	} else {
-		resp.Diagnostics.AddError("Error reading container", "Could not convert scaling to object type")
+	state.Scaling, diags = buildScalingFromApi(container)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	state.Replicas = types.Int64Value(int64(container.NumberOfReplicas))
 
 	state.Status = types.StringValue(container.State)
+	state.CurrentReplicas = types.Int64Value(int64(container.AvailableReplicas))
+	if state.WaitForReady.IsNull() {
+		state.WaitForReady = types.BoolValue(false)
+	}
+	if state.RollbackOnFailure.IsNull() {
+		state.RollbackOnFailure = types.BoolValue(false)
+	}
 
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
@@ -994,6 +1034,9 @@ func (r *containerResource) Read(ctx context.Context, req resource.ReadRequest,
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *containerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_container.Update")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var plan containerResource
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -1005,7 +1048,11 @@ func (r *containerResource) Update(ctx context.Context, req resource.UpdateReque
 		plan.Registry = types.StringNull()
 	}
 
-	containerResources := api.ContainerResources(plan.Resources.ValueString())
+	containerResources, diags := buildContainerResourcesInput(ctx, plan.Resources)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Build input struct
 	input := api.ContainerModifyInput{
@@ -1082,9 +1129,52 @@ func (r *containerResource) Update(ctx context.Context, req resource.UpdateReque
 	// Environment variables
 	inputsUpd, dEnvU := buildEnvUpdateInputs(ctx, plan.EnvironmentVariables, prev.EnvironmentVariables)
 	resp.Diagnostics.Append(dEnvU...)
+	secretHashes, dEnvU := readSecretEnvHashes(ctx, req.Private)
+	resp.Diagnostics.Append(dEnvU...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	secretHashes = recordSecretEnvHashes(secretHashes, inputsUpd)
+	writeOnlyNames, dEnvU := writeOnlyEnvNames(ctx, req.Private)
+	resp.Diagnostics.Append(dEnvU...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	// Write-only values are only sent when their version changes, as the
+	// previous values cannot be compared with the configuration.
+	if !plan.SecretEnvWOVersion.Equal(prev.SecretEnvWOVersion) {
+		writeOnlyEnv, dEnvU := readWriteOnlyEnv(ctx, req.Config)
+		resp.Diagnostics.Append(dEnvU...)
+		writeOnlyInputs, dEnvU := writeOnlyEnvInputs(writeOnlyEnv, inputsUpd)
+		resp.Diagnostics.Append(dEnvU...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		inputsUpd = append(inputsUpd, writeOnlyInputs...)
+		for name := range writeOnlyNames {
+			if _, ok := writeOnlyEnv[name]; !ok {
+				inputsUpd = append(inputsUpd, api.EnvironmentVariableInput{Name: name, State: api.StateAbsent})
+			}
+		}
+		writeOnlyNames = map[string]bool{}
+		for name := range writeOnlyEnv {
+			writeOnlyNames[name] = true
+		}
+	}
+	// Variables injected by env_from are always resent so that changed
+	// connection details reach the container.
+	envFromInputs, dEnvU := buildEnvFromInputs(ctx, r.nexaaClient.API, plan.EnvFrom, inputsUpd)
+	resp.Diagnostics.Append(dEnvU...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	inputsUpd = append(inputsUpd, envFromInputs...)
+	for name := range envFromNames(ctx, prev.EnvFrom) {
+		if !writeOnlyNames[name] && !envInputPresent(inputsUpd, name) {
+			inputsUpd = append(inputsUpd, api.EnvironmentVariableInput{Name: name, State: api.StateAbsent})
+		}
+	}
+	injectedNames := envFromNames(ctx, plan.EnvFrom)
 	if len(inputsUpd) > 0 {
 		input.EnvironmentVariables = inputsUpd
 	}
@@ -1098,63 +1188,12 @@ func (r *containerResource) Update(ctx context.Context, req resource.UpdateReque
 	input.HealthCheck = healthCheck
 
 	// Scaling
-	var scaling scalingResource
-	diags = plan.Scaling.As(ctx, &scaling, basetypes.ObjectAsOptions{})
+	scalingInput, diags := buildScalingInput(ctx, plan.Scaling)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-
-	// Validate scaling configuration
-	if err := validateScalingConfig(scaling); err != nil {
-		resp.Diagnostics.AddError("Invalid scaling configuration", err.Error())
-		return
-	}
-
-	switch scaling.Type.ValueString() {
-	case "auto":
-		if !scaling.AutoInput.IsNull() && !scaling.AutoInput.IsUnknown() {
-			var autoInput autoscaleResource
-			diags := scaling.AutoInput.As(ctx, &autoInput, basetypes.ObjectAsOptions{})
-			resp.Diagnostics.Append(diags...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
-
-			var triggers []triggerResource
-			diags = autoInput.Triggers.ElementsAs(ctx, &triggers, false)
-			resp.Diagnostics.Append(diags...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
-
-			auto := api.AutoScalingInput{
-				Replicas: api.ReplicasInput{
-					Minimum: int(autoInput.MinimalReplicas.ValueInt64()),
-					Maximum: int(autoInput.MaximalReplicas.ValueInt64()),
-				},
-			}
-
-			for _, t := range triggers {
-				auto.Triggers = append(auto.Triggers, api.AutoScalingTriggerInput{
-					Type:      api.AutoScalingType(t.Type.ValueString()),
-					Threshold: int(t.Threshold.ValueInt64()),
-				})
-			}
-
-			input.Scaling = &api.ScalingInput{Auto: &auto}
-		}
-
-	case "manual":
-		if !scaling.Manualinput.IsNull() && !scaling.Manualinput.IsUnknown() {
-			replicas := int(scaling.Manualinput.ValueInt64())
-			input.Scaling = &api.ScalingInput{
-				Manual: &api.ManualScalingInput{
-					Replicas: replicas,
-				},
-			}
-		}
-	}
+	input.Scaling = scalingInput
 
 	updateTimeout, diags := plan.Timeouts.Update(ctx, 30*time.Second)
 
@@ -1168,7 +1207,7 @@ func (r *containerResource) Update(ctx context.Context, req resource.UpdateReque
 	defer cancel()
 
 	client := r.nexaaClient.API
-	err := waitForUnlocked(ctx, containerLocked(), client, plan.Namespace.ValueString(), plan.Name.ValueString())
+	err := waitForUnlocked(ctx, containerLocked(), client, r.nexaaClient.PollInterval, plan.Namespace.ValueString(), plan.Name.ValueString())
 
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating container", "Cannot modify container in current state "+err.Error())
@@ -1176,6 +1215,8 @@ func (r *containerResource) Update(ctx context.Context, req resource.UpdateReque
 	}
 
 	// modify containerResult
+	// The whole configuration is sent on every update, so a change to only
+	// redeploy_trigger still reaches the API and redeploys the container.
 	containerResult, err := client.ContainerModify(input)
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating container", "Could not update container: "+err.Error())
@@ -1196,7 +1237,11 @@ func (r *containerResource) Update(ctx context.Context, req resource.UpdateReque
 
 	plan.Registry = processRegistryName(containerResult)
 
-	plan.Resources = types.StringValue(string(containerResult.Resources))
+	plan.Resources, diags = buildContainerResourcesFromApi(containerResult.Resources)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Command
 	plan.Command, diags = buildCommandState(containerResult.Command)
@@ -1214,7 +1259,8 @@ func (r *containerResource) Update(ctx context.Context, req resource.UpdateReque
 
 	// Environment variables (update state)
 	if containerResult.EnvironmentVariables != nil {
-		setVal, d := buildEnvSetFromAPI(ctx, containerResult.EnvironmentVariables, input.EnvironmentVariables, plan.EnvironmentVariables, secretUseProvided)
+		apiVars := withoutEnvNames(withoutEnvNames(containerResult.EnvironmentVariables, writeOnlyNames), injectedNames)
+		setVal, d := buildEnvSetFromAPI(ctx, apiVars, input.EnvironmentVariables, plan.EnvironmentVariables, secretUseProvided)
 		resp.Diagnostics.Append(d...)
 		if resp.Diagnostics.HasError() {
 			return
@@ -1232,7 +1278,7 @@ func (r *containerResource) Update(ctx context.Context, req resource.UpdateReque
 
 	// Mounts
 	if containerResult.Mounts != nil {
-		mountList, d := buildMountsFromApi(containerResult.Mounts)
+		mountList, d := buildMountsFromApi(ctx, containerResult.Mounts, plan.Mounts)
 		resp.Diagnostics.Append(d...)
 		if resp.Diagnostics.HasError() {
 			return
@@ -1260,96 +1306,29 @@ func (r *containerResource) Update(ctx context.Context, req resource.UpdateReque
 	plan.HealthCheck = buildHealthCheckState(containerResult)
 
 	// Scaling
-	autoInputType := types.ObjectType{
-		AttrTypes: map[string]attr.Type{
-			"minimal_replicas": types.Int64Type,
-			"maximal_replicas": types.Int64Type,
-			"triggers": types.ListType{
-				ElemType: types.ObjectType{
-					AttrTypes: map[string]attr.Type{
-						"type":      types.StringType,
-						"threshold": types.Int64Type,
-					},
-				},
-			},
-		},
-	}
-
-	scalingType := types.ObjectType{
-		AttrTypes: map[string]attr.Type{
-			"type":         types.StringType,
-			"manual_input": types.Int64Type,
-			"auto_input":   autoInputType,
-		},
-	}
-
-	var scalingObj attr.Value = types.ObjectNull(scalingType.AttrTypes)
-
-	if containerResult.AutoScaling != nil {
-		var triggerVals []attr.Value
-		for _, t := range containerResult.AutoScaling.Triggers {
-			triggerObj := types.ObjectValueMust(
-				map[string]attr.Type{
-					"type":      types.StringType,
-					"threshold": types.Int64Type,
-				},
-				map[string]attr.Value{
-					"type":      types.StringValue(strings.ToUpper(t.Type)),
-					"threshold": types.Int64Value(int64(t.Threshold)),
-				},
-			)
-			triggerVals = append(triggerVals, triggerObj)
-		}
-
-		triggersList, diags := types.ListValue(
-			types.ObjectType{
-				AttrTypes: map[string]attr.Type{
-					"type":      types.StringType,
-					"threshold": types.Int64Type,
-				},
-			}, triggerVals)
-		if diags.HasError() {
-			resp.Diagnostics.AddError("Scaling triggers error", "Failed to build scaling triggers list")
-			return
-		}
-
-		autoInput := types.ObjectValueMust(
-			autoInputType.AttrTypes,
-			map[string]attr.Value{
-				"minimal_replicas": types.Int64Value(int64(containerResult.AutoScaling.Replicas.Minimum)),
-				"maximal_replicas": types.Int64Value(int64(containerResult.AutoScaling.Replicas.Maximum)),
-				"triggers":         triggersList,
-			},
-		)
-
-		scalingObj = types.ObjectValueMust(
-			scalingType.AttrTypes,
-			map[string]attr.Value{
-				"type":         types.StringValue("auto"),
-				"manual_input": types.Int64Null(),
-				"auto_input":   autoInput,
-			},
-		)
-	} else if containerResult.NumberOfReplicas >= 0 {
-		scalingObj = types.ObjectValueMust(
-			scalingType.AttrTypes,
-			map[string]attr.Value{
-				"type":         types.StringValue("manual"),
-				"manual_input": types.Int64Value(int64(containerResult.NumberOfReplicas)),
-				"auto_input":   types.ObjectNull(autoInputType.AttrTypes),
-			},
-		)
-	}
-
-	if obj, ok := scalingObj.(types.Object); ok {
-		plan.Scaling = obj
-	} else {
-		resp.Diagnostics.AddError("Error updating container", "Could not convert scaling to object type")
+	plan.Scaling, diags = buildScalingFromApi(containerResult)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	plan.Replicas = types.Int64Value(int64(containerResult.NumberOfReplicas))
 
 	plan.Status = prev.Status
+	plan.CurrentReplicas = types.Int64Value(int64(containerResult.AvailableReplicas))
+
+	if plan.WaitForReady.ValueBool() || plan.RollbackOnFailure.ValueBool() {
+		if _, err := waitForContainerReady(ctx, client, r.nexaaClient.PollInterval, plan.Namespace.ValueString(), plan.Name.ValueString()); err != nil {
+			if !plan.RollbackOnFailure.ValueBool() {
+				resp.Diagnostics.AddError("Container is not ready", err.Error())
+			} else {
+				r.rollbackUpdate(ctx, prev, plan, err, resp)
+				return
+			}
+		}
+	}
 
+	resp.Diagnostics.Append(storeWriteOnlyEnvNames(ctx, resp.Private, writeOnlyNames)...)
+	resp.Diagnostics.Append(storeSecretEnvHashes(ctx, resp.Private, secretHashes)...)
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -1367,8 +1346,35 @@ func (r *containerResource) Update(ctx context.Context, req resource.UpdateReque
 	resp.Diagnostics.Append(resp.Identity.Set(ctx, identity)...)
 }
 
+// rollbackUpdate re-applies the previous configuration after an update that
+// did not become ready. The previous state is kept when the rollback succeeds,
+// so the next plan shows the failed change again.
+func (r *containerResource) rollbackUpdate(ctx context.Context, prev containerResource, failed containerResource, readyErr error, resp *resource.UpdateResponse) {
+	input, diags := buildContainerRollbackInput(ctx, prev, failed)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		resp.Diagnostics.AddError("Container is not ready", readyErr.Error()+". The previous configuration could not be restored.")
+		resp.Diagnostics.Append(resp.State.Set(ctx, failed)...)
+		return
+	}
+
+	if err := rollbackContainer(ctx, r.nexaaClient.API, r.nexaaClient.PollInterval, input); err != nil {
+		resp.Diagnostics.AddError("Container is not ready",
+			readyErr.Error()+". Restoring the previous configuration failed: "+err.Error())
+		resp.Diagnostics.Append(resp.State.Set(ctx, failed)...)
+		return
+	}
+
+	resp.Diagnostics.AddError("Container is not ready",
+		readyErr.Error()+". The previous configuration has been restored.")
+	resp.Diagnostics.Append(resp.State.Set(ctx, prev)...)
+}
+
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *containerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_container.Delete")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var plan containerResource
 	diags := req.State.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -1388,15 +1394,19 @@ func (r *containerResource) Delete(ctx context.Context, req resource.DeleteReque
 	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
 	defer cancel()
 
-	err := waitForUnlocked(ctx, containerLocked(), client, plan.Namespace.ValueString(), plan.Name.ValueString())
+	err := waitForUnlocked(ctx, containerLocked(), client, r.nexaaClient.PollInterval, plan.Namespace.ValueString(), plan.Name.ValueString())
 
+	// A container deleted outside of Terraform is already gone.
+	if isNotFoundErr(err) {
+		return
+	}
 	if err != nil {
-		resp.Diagnostics.AddError("Error deleting container", "Could not reach a running state: "+err.Error())
+		resp.Diagnostics.AddError("Error deleting container", "Could not reach an unlocked state: "+err.Error())
 		return
 	}
 
 	_, err = client.ContainerDelete(plan.Namespace.ValueString(), plan.Name.ValueString())
-	if err != nil {
+	if err != nil && !isNotFoundErr(err) {
 		resp.Diagnostics.AddError(
 			"Error deleting container",
 			fmt.Sprintf("Failed to delete container %q: %s", plan.Name.ValueString(), err.Error()),
@@ -1406,9 +1416,9 @@ func (r *containerResource) Delete(ctx context.Context, req resource.DeleteReque
 }
 
 func (r *containerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	namespace, name, err := parseContainerImportID(req.ID)
-	if err != nil {
-		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+	namespace, name, diags := containerImportTarget(ctx, req)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -1431,85 +1441,16 @@ func (r *containerResource) ImportState(ctx context.Context, req resource.Import
 	}
 
 	// Build scaling state (not included in common function since starter containers don't have scaling)
-	autoInputType := types.ObjectType{
-		AttrTypes: map[string]attr.Type{
-			"minimal_replicas": types.Int64Type,
-			"maximal_replicas": types.Int64Type,
-			"triggers": types.ListType{
-				ElemType: types.ObjectType{
-					AttrTypes: map[string]attr.Type{
-						"type":      types.StringType,
-						"threshold": types.Int64Type,
-					},
-				},
-			},
-		},
-	}
-
-	scalingType := types.ObjectType{
-		AttrTypes: map[string]attr.Type{
-			"type":         types.StringType,
-			"manual_input": types.Int64Type,
-			"auto_input":   autoInputType,
-		},
+	scaling, diags := buildScalingFromApi(container)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	var scalingObj attr.Value = types.ObjectNull(scalingType.AttrTypes)
-
-	if container.AutoScaling != nil {
-		var triggerVals []attr.Value
-		for _, t := range container.AutoScaling.Triggers {
-			triggerObj := types.ObjectValueMust(
-				map[string]attr.Type{
-					"type":      types.StringType,
-					"threshold": types.Int64Type,
-				},
-				map[string]attr.Value{
-					"type":      types.StringValue(strings.ToUpper(t.Type)),
-					"threshold": types.Int64Value(int64(t.Threshold)),
-				},
-			)
-			triggerVals = append(triggerVals, triggerObj)
-		}
-
-		triggersList, diags := types.ListValue(
-			types.ObjectType{
-				AttrTypes: map[string]attr.Type{
-					"type":      types.StringType,
-					"threshold": types.Int64Type,
-				},
-			}, triggerVals)
-		if diags.HasError() {
-			resp.Diagnostics.AddError("Scaling triggers error", "Failed to build scaling triggers list")
-			return
-		}
-
-		autoInput := types.ObjectValueMust(
-			autoInputType.AttrTypes,
-			map[string]attr.Value{
-				"minimal_replicas": types.Int64Value(int64(container.AutoScaling.Replicas.Minimum)),
-				"maximal_replicas": types.Int64Value(int64(container.AutoScaling.Replicas.Maximum)),
-				"triggers":         triggersList,
-			},
-		)
-
-		scalingObj = types.ObjectValueMust(
-			scalingType.AttrTypes,
-			map[string]attr.Value{
-				"type":         types.StringValue("auto"),
-				"manual_input": types.Int64Null(),
-				"auto_input":   autoInput,
-			},
-		)
-	} else if container.NumberOfReplicas >= 0 {
-		scalingObj = types.ObjectValueMust(
-			scalingType.AttrTypes,
-			map[string]attr.Value{
-				"type":         types.StringValue("manual"),
-				"manual_input": types.Int64Value(int64(container.NumberOfReplicas)),
-				"auto_input":   types.ObjectNull(autoInputType.AttrTypes),
-			},
-		)
+	containerResources, diags := buildContainerResourcesFromApi(container.Resources)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Create state using common values and add scaling + timeouts
@@ -1519,24 +1460,25 @@ func (r *containerResource) ImportState(ctx context.Context, req resource.Import
 		Namespace:            stateValues["namespace"].(types.String),
 		Image:                stateValues["image"].(types.String),
 		Registry:             stateValues["registry"].(types.String),
-		Resources:            types.StringValue(string(container.Resources)),
+		Resources:            containerResources,
 		Command:              stateValues["command"].(types.List),
 		Entrypoint:           stateValues["entrypoint"].(types.List),
 		EnvironmentVariables: stateValues["environment_variables"].(types.Set),
+		SecretEnvWO:          types.MapNull(types.StringType),
+		SecretEnvWOVersion:   types.Int64Null(),
+		EnvFrom:              types.ListNull(envFromObjectType()),
 		Ports:                stateValues["ports"].(types.List),
 		Ingresses:            stateValues["ingresses"].(types.List),
 		ExternalConnection:   stateValues["external_connection"].(types.Object),
 		Mounts:               stateValues["mounts"].(types.List),
 		HealthCheck:          stateValues["health_check"].(types.Object),
+		Scaling:              scaling,
+		Replicas:             types.Int64Value(int64(container.NumberOfReplicas)),
 		Status:               stateValues["status"].(types.String),
-	}
-
-	// Add scaling (specific to regular containers)
-	if obj, ok := scalingObj.(types.Object); ok {
-		state.Scaling = obj
-	} else {
-		resp.Diagnostics.AddError("Error importing container", "Failed to build scaling object")
-		return
+		CurrentReplicas:      types.Int64Value(int64(container.AvailableReplicas)),
+		WaitForReady:         types.BoolValue(false),
+		RedeployTrigger:      types.MapNull(types.StringType),
+		RollbackOnFailure:    types.BoolValue(false),
 	}
 
 	// Add timeouts
@@ -1544,11 +1486,13 @@ func (r *containerResource) ImportState(ctx context.Context, req resource.Import
 		Object: types.ObjectValueMust(
 			map[string]attr.Type{
 				"create": types.StringType,
+				"read":   types.StringType,
 				"update": types.StringType,
 				"delete": types.StringType,
 			},
 			map[string]attr.Value{
-				"create": types.StringValue("30s"),
+				"create": types.StringValue("5m"),
+				"read":   types.StringValue("30s"),
 				"update": types.StringValue("30s"),
 				"delete": types.StringValue("2m"),
 			},
@@ -1556,6 +1500,10 @@ func (r *containerResource) ImportState(ctx context.Context, req resource.Import
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, containerIdentity{
+		Name:      types.StringValue(name),
+		Namespace: types.StringValue(namespace),
+	})...)
 }
 func processRegistryName(containerResult api.ContainerResult) types.String {
 	if containerResult.PrivateRegistry != nil {
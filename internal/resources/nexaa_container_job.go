@@ -6,27 +6,35 @@ package resources
 import (
 	"context"
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/nexaa-cloud/nexaa-cli/api"
 	nexaaclient "github.com/nexaa-cloud/terraform-provider-nexaa/internal/client"
+	"github.com/nexaa-cloud/terraform-provider-nexaa/internal/enums"
+	"github.com/nexaa-cloud/terraform-provider-nexaa/internal/tracing"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &containerJobResource{}
-	_ resource.ResourceWithImportState = &containerJobResource{}
-	_ resource.ResourceWithConfigure   = &containerJobResource{}
+	_ resource.Resource                   = &containerJobResource{}
+	_ resource.ResourceWithImportState    = &containerJobResource{}
+	_ resource.ResourceWithIdentity       = &containerJobResource{}
+	_ resource.ResourceWithConfigure      = &containerJobResource{}
+	_ resource.ResourceWithModifyPlan     = &containerJobResource{}
+	_ resource.ResourceWithValidateConfig = &containerJobResource{}
+	_ resource.ResourceWithUpgradeState   = &containerJobResource{}
 )
 
 // NewContainerJobResource is a helper function to simplify the provider implementation.
@@ -55,8 +63,11 @@ type containerJobResource struct {
 	Namespace            types.String   `tfsdk:"namespace"`
 	Image                types.String   `tfsdk:"image"`
 	Registry             types.String   `tfsdk:"registry"`
-	Resources            types.String   `tfsdk:"resources"`
+	Resources            types.Object   `tfsdk:"resources"`
 	EnvironmentVariables types.Set      `tfsdk:"environment_variables"`
+	SecretEnvWO          types.Map      `tfsdk:"secret_environment_variables_wo"`
+	SecretEnvWOVersion   types.Int64    `tfsdk:"secret_environment_variables_wo_version"`
+	EnvFrom              types.List     `tfsdk:"env_from"`
 	Command              types.List     `tfsdk:"command"`
 	Entrypoint           types.List     `tfsdk:"entrypoint"`
 	Mounts               types.List     `tfsdk:"mounts"`
@@ -71,8 +82,54 @@ func (r *containerJobResource) Metadata(_ context.Context, req resource.Metadata
 	resp.TypeName = req.ProviderTypeName + "_container_job"
 }
 
+// ModifyPlan fills in the namespace from the provider's default_namespace when it is omitted.
+func (r *containerJobResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	applyDefaultNamespace(ctx, r.nexaaClient, req, resp)
+}
+
+// ValidateConfig warns about $(NAME) references in command and entrypoint to
+// environment variables the job does not set.
+func (r *containerJobResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config containerJobResource
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	names, known := configuredEnvNames(ctx, config.EnvironmentVariables, config.SecretEnvWO, config.EnvFrom)
+	if !known {
+		return
+	}
+	resp.Diagnostics.Append(warnUndefinedCommandEnv(ctx, "command", config.Command, names)...)
+	resp.Diagnostics.Append(warnUndefinedCommandEnv(ctx, "entrypoint", config.Entrypoint, names)...)
+}
+
+func (r *containerJobResource) IdentitySchema(ctx context.Context, request resource.IdentitySchemaRequest, response *resource.IdentitySchemaResponse) {
+	response.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"name": identityschema.StringAttribute{
+				Description:       "The name of the container job.",
+				RequiredForImport: true,
+			},
+			"namespace": identityschema.StringAttribute{
+				Description:       "The namespace where the container job belongs to.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+// UpgradeState converts state written before resources became a cpu/ram
+// object, the same way as for nexaa_container.
+func (r *containerJobResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {StateUpgrader: upgradeContainerStateV0},
+	}
+}
+
 func (r *containerJobResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     1,
 		Description: "Container job resource representing a scheduled container job that will be deployed on nexaa.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -82,10 +139,17 @@ func (r *containerJobResource) Schema(ctx context.Context, _ resource.SchemaRequ
 			"name": schema.StringAttribute{
 				Required:    true,
 				Description: "Name of the container job",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"namespace": schema.StringAttribute{
-				Required:    true,
-				Description: "Name of the namespace that the container job will belong to",
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of the namespace that the container job will belong to, defaults to the default_namespace of the provider",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"image": schema.StringAttribute{
 				Required:    true,
@@ -95,9 +159,28 @@ func (r *containerJobResource) Schema(ctx context.Context, _ resource.SchemaRequ
 				Optional:    true,
 				Description: "The name of the registry used to access images that are saved in a private environment, leave empty to use a public registry",
 			},
-			"resources": schema.StringAttribute{
+			"resources": schema.SingleNestedAttribute{
+				Attributes: map[string]schema.Attribute{
+					"cpu": schema.Float64Attribute{
+						Required:    true,
+						Description: "The amount of cpu used for the container job, can be the following values: 0.25, 0.5, 0.75, 1, 2, 3, 4",
+						Validators: []validator.Float64{
+							float64validator.OneOf(enums.CPU...),
+						},
+					},
+					"ram": schema.Float64Attribute{
+						Required:    true,
+						Description: "The amount of memory used for the container job (in GB), can be the following values: 0.5, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16",
+						Validators: []validator.Float64{
+							float64validator.OneOf(enums.RAM...),
+						},
+					},
+				},
 				Required:    true,
-				Description: "The resources used for running the container job, this can be gotten via the nexaa_container_resources data source, with specifying the amount of cpu and memory",
+				Description: "The resources used for running the container job, only specific combinations of cpu and memory are available",
+				Validators: []validator.Object{
+					containerResourcesValidator{},
+				},
 			},
 			"environment_variables": schema.SetNestedAttribute{
 				NestedObject: schema.NestedAttributeObject{
@@ -123,6 +206,33 @@ func (r *containerJobResource) Schema(ctx context.Context, _ resource.SchemaRequ
 				Computed:    true,
 				Description: "Environment variables used in the container job; order is not significant and matched by name",
 			},
+			"secret_environment_variables_wo": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				WriteOnly:   true,
+				Sensitive:   true,
+				Description: "Secret environment variables whose values are never stored in the Terraform state or plan, keyed by name. The values are only sent when secret_environment_variables_wo_version changes. Requires Terraform 1.11 or later.",
+			},
+			"secret_environment_variables_wo_version": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Version of secret_environment_variables_wo. Change it to send new values of the write-only environment variables to the API.",
+			},
+			"env_from": schema.ListNestedAttribute{
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"cloud_database_cluster_user": schema.StringAttribute{
+							Required:    true,
+							Description: "The id of the nexaa_cloud_database_cluster_user whose connection details are injected",
+						},
+						"prefix": schema.StringAttribute{
+							Optional:    true,
+							Description: "Prefix of the injected HOST, PORT, USER and PASSWORD variables, defaults to DATABASE_",
+						},
+					},
+				},
+				Optional:    true,
+				Description: "Injects the connection details of a cloud database cluster user as environment variables. The password is read from the credentials of the user and injected as a secret variable. The injected variables are not part of environment_variables",
+			},
 			"command": schema.ListAttribute{
 				ElementType: types.StringType,
 				Optional:    true,
@@ -146,6 +256,16 @@ func (r *containerJobResource) Schema(ctx context.Context, _ resource.SchemaRequ
 							Required:    true,
 							Description: "The name of the volume that is used for the mount",
 						},
+						"auto_create": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(false),
+							Description: "Create the volume when it does not exist yet. The created volume is not managed by Terraform and is kept when the mount is removed",
+						},
+						"size": schema.Int64Attribute{
+							Optional:    true,
+							Description: "Size of the volume in GB, min 1GB/ max 100GB. Used when the volume is auto created, a larger size increases an existing volume",
+						},
 					},
 				},
 				Computed:    true,
@@ -155,6 +275,9 @@ func (r *containerJobResource) Schema(ctx context.Context, _ resource.SchemaRequ
 			"schedule": schema.StringAttribute{
 				Required:    true,
 				Description: "Cron notation to schedule jobs. Format is equal to regular cron notation. For example, to run a job every day at 4am, use `0 4 * * *`. You can use https://crontab.guru/ to help you build your cron expressions.",
+				Validators: []validator.String{
+					cronScheduleValidator{},
+				},
 			},
 			"enabled": schema.BoolAttribute{
 				Optional:    true,
@@ -182,6 +305,9 @@ func (r *containerJobResource) Schema(ctx context.Context, _ resource.SchemaRequ
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *containerJobResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_container_job.Create")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var plan containerJobResource
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -202,13 +328,19 @@ func (r *containerJobResource) Create(ctx context.Context, req resource.CreateRe
 		plan.Registry = types.StringNull()
 	}
 
+	containerJobResources, diags := buildContainerResourcesInput(ctx, plan.Resources)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Build input struct
 	input := api.ContainerJobCreateInput{
 		Namespace: plan.Namespace.ValueString(),
 		Name:      plan.Name.ValueString(),
 		Image:     plan.Image.ValueString(),
 		Registry:  plan.Registry.ValueStringPointer(),
-		Resources: api.ContainerResources(plan.Resources.ValueString()),
+		Resources: containerJobResources,
 		Schedule:  plan.Schedule.ValueString(),
 		Enabled:   plan.Enabled.ValueBool(),
 	}
@@ -239,31 +371,16 @@ func (r *containerJobResource) Create(ctx context.Context, req resource.CreateRe
 		}
 		for _, m := range mounts {
 			input.Mounts = append(input.Mounts, api.MountInput{
-				Path: m.Path.ValueString(),
-				Volume: api.MountVolumeInput{
-					Name:       m.Volume.ValueString(),
-					AutoCreate: false,
-					Increase:   false,
-					Size:       nil,
-				},
-				State: api.StatePresent,
+				Path:   m.Path.ValueString(),
+				Volume: buildMountVolumeInput(m, nil),
+				State:  api.StatePresent,
 			})
 		}
 	} else {
 		input.Mounts = []api.MountInput{}
 	}
 
-	// Environment variables (build API input from plan)
-	inputs, dEnv := extractEnvInputsFromSet(ctx, plan.EnvironmentVariables)
-	resp.Diagnostics.Append(dEnv...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-	if len(inputs) > 0 {
-		input.EnvironmentVariables = inputs
-	}
-
-	// Create container job
+	// Refuse to take over a container job that already exists
 	r.nexaaClient.Lock("container-job:" + plan.Namespace.ValueString() + "/" + plan.Name.ValueString())
 	defer r.nexaaClient.Unlock("container-job:" + plan.Namespace.ValueString() + "/" + plan.Name.ValueString())
 
@@ -279,13 +396,44 @@ func (r *containerJobResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	// Environment variables (build API input from plan)
+	inputs, dEnv := extractEnvInputsFromSet(ctx, plan.EnvironmentVariables)
+	resp.Diagnostics.Append(dEnv...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	secretHashes := recordSecretEnvHashes(newSecretEnvHashes(), inputs)
+	writeOnlyEnv, dEnv := readWriteOnlyEnv(ctx, req.Config)
+	resp.Diagnostics.Append(dEnv...)
+	writeOnlyInputs, dEnv := writeOnlyEnvInputs(writeOnlyEnv, inputs)
+	resp.Diagnostics.Append(dEnv...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	inputs = append(inputs, writeOnlyInputs...)
+	envFromInputs, dEnv := buildEnvFromInputs(ctx, r.nexaaClient.API, plan.EnvFrom, inputs)
+	resp.Diagnostics.Append(dEnv...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	inputs = append(inputs, envFromInputs...)
+	if len(inputs) > 0 {
+		input.EnvironmentVariables = inputs
+	}
+	writeOnlyNames := map[string]bool{}
+	for name := range writeOnlyEnv {
+		writeOnlyNames[name] = true
+	}
+	injectedNames := envFromNames(ctx, plan.EnvFrom)
+
+	// Create container job
 	containerJobResult, err := client.ContainerJobCreate(input)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating container job", "Could not create container job: "+err.Error())
 		return
 	}
 
-	err = waitForUnlocked(ctx, containerJobLocked(), client, plan.Namespace.ValueString(), plan.Name.ValueString())
+	err = waitForUnlocked(ctx, containerJobLocked(), client, r.nexaaClient.PollInterval, plan.Namespace.ValueString(), plan.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating container job", "Could not reach a unlocked state: "+err.Error())
 		return
@@ -306,17 +454,18 @@ func (r *containerJobResource) Create(ctx context.Context, req resource.CreateRe
 	plan.Enabled = types.BoolValue(containerJobResult.Enabled)
 	plan.State = types.StringValue(containerJobResult.State)
 
-	if containerJobResult.PrivateRegistry == nil || containerJobResult.PrivateRegistry.Name == "public" {
-		plan.Registry = types.StringNull()
-	} else {
-		plan.Registry = types.StringValue(*input.Registry)
-	}
+	plan.Registry = processJobRegistryName(containerJobResult)
 
-	plan.Resources = types.StringValue(string(containerJobResult.Resources))
+	plan.Resources, diags = buildContainerResourcesFromApi(containerJobResult.Resources)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Environment variables (state population)
 	if containerJobResult.EnvironmentVariables != nil {
-		setVal, d := buildEnvSetFromAPI(ctx, containerJobResult.EnvironmentVariables, input.EnvironmentVariables, types.SetNull(envVarObjectType()), secretUseProvided)
+		apiVars := withoutEnvNames(withoutEnvNames(containerJobResult.EnvironmentVariables, writeOnlyNames), injectedNames)
+		setVal, d := buildEnvSetFromAPI(ctx, apiVars, input.EnvironmentVariables, types.SetNull(envVarObjectType()), secretUseProvided)
 		resp.Diagnostics.Append(d...)
 		if resp.Diagnostics.HasError() {
 			return
@@ -340,7 +489,7 @@ func (r *containerJobResource) Create(ctx context.Context, req resource.CreateRe
 
 	// Mounts
 	if containerJobResult.Mounts != nil {
-		mountList, d := buildMountsFromApi(containerJobResult.Mounts)
+		mountList, d := buildMountsFromApi(ctx, containerJobResult.Mounts, plan.Mounts)
 		resp.Diagnostics.Append(d...)
 		if resp.Diagnostics.HasError() {
 			return
@@ -350,12 +499,21 @@ func (r *containerJobResource) Create(ctx context.Context, req resource.CreateRe
 
 	plan.State = types.StringValue(containerJobResult.State)
 
+	resp.Diagnostics.Append(storeWriteOnlyEnvNames(ctx, resp.Private, writeOnlyNames)...)
+	resp.Diagnostics.Append(storeSecretEnvHashes(ctx, resp.Private, secretHashes)...)
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, containerIdentity{
+		Name:      plan.Name,
+		Namespace: plan.Namespace,
+	})...)
 }
 
 // Read refreshes the Terraform state with the latest data.
 func (r *containerJobResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_container_job.Read")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var state containerJobResource
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -384,17 +542,30 @@ func (r *containerJobResource) Read(ctx context.Context, req resource.ReadReques
 	state.Enabled = types.BoolValue(containerJob.Enabled)
 	state.State = types.StringValue(containerJob.State)
 
-	if containerJob.PrivateRegistry == nil || containerJob.PrivateRegistry.Name == "public" {
-		state.Registry = types.StringNull()
-	} else {
-		state.Registry = types.StringValue(containerJob.PrivateRegistry.Name)
-	}
+	state.Registry = processJobRegistryName(containerJob)
 
-	state.Resources = types.StringValue(string(containerJob.Resources))
+	state.Resources, diags = buildContainerResourcesFromApi(containerJob.Resources)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Environment variables (refresh state)
+	writeOnlyNames, diags := writeOnlyEnvNames(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	if containerJob.EnvironmentVariables != nil {
-		setVal, d := buildEnvSetFromAPI(ctx, containerJob.EnvironmentVariables, nil, state.EnvironmentVariables, secretPreservePrev)
+		apiVars := withoutEnvNames(withoutEnvNames(containerJob.EnvironmentVariables, writeOnlyNames), envFromNames(ctx, state.EnvFrom))
+		setVal, d := buildEnvSetFromAPI(ctx, apiVars, nil, state.EnvironmentVariables, secretPreservePrev)
+		resp.Diagnostics.Append(d...)
+		secretHashes, d := readSecretEnvHashes(ctx, req.Private)
+		resp.Diagnostics.Append(d...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		setVal, d = maskStaleSecrets(ctx, setVal, secretHashes)
 		resp.Diagnostics.Append(d...)
 		if resp.Diagnostics.HasError() {
 			return
@@ -418,7 +589,7 @@ func (r *containerJobResource) Read(ctx context.Context, req resource.ReadReques
 
 	// Mounts
 	if containerJob.Mounts != nil {
-		mountList, d := buildMountsFromApi(containerJob.Mounts)
+		mountList, d := buildMountsFromApi(ctx, containerJob.Mounts, state.Mounts)
 		resp.Diagnostics.Append(d...)
 		if resp.Diagnostics.HasError() {
 			return
@@ -428,10 +599,17 @@ func (r *containerJobResource) Read(ctx context.Context, req resource.ReadReques
 
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, containerIdentity{
+		Name:      state.Name,
+		Namespace: state.Namespace,
+	})...)
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *containerJobResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_container_job.Update")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var plan containerJobResource
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -443,7 +621,11 @@ func (r *containerJobResource) Update(ctx context.Context, req resource.UpdateRe
 		plan.Registry = types.StringNull()
 	}
 
-	containerJobResources := api.ContainerResources(plan.Resources.ValueString())
+	containerJobResources, diags := buildContainerResourcesInput(ctx, plan.Resources)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Build input struct
 	input := api.ContainerJobModifyInput{
@@ -487,6 +669,11 @@ func (r *containerJobResource) Update(ctx context.Context, req resource.UpdateRe
 		}
 	}
 
+	prevByKey := map[string]*mountResource{}
+	for i := range previousMounts {
+		prevByKey[mountKey(previousMounts[i].Path.ValueString(), previousMounts[i].Volume.ValueString())] = &previousMounts[i]
+	}
+
 	input.Mounts = []api.MountInput{}
 	plannedMounts := map[string]struct{}{}
 	if !plan.Mounts.IsNull() && !plan.Mounts.IsUnknown() {
@@ -497,22 +684,17 @@ func (r *containerJobResource) Update(ctx context.Context, req resource.UpdateRe
 			return
 		}
 		for _, m := range mounts {
-			key := fmt.Sprintf("%s|%s", m.Path.ValueString(), m.Volume.ValueString())
+			key := mountKey(m.Path.ValueString(), m.Volume.ValueString())
 			plannedMounts[key] = struct{}{}
 			input.Mounts = append(input.Mounts, api.MountInput{
-				Path: m.Path.ValueString(),
-				Volume: api.MountVolumeInput{
-					Name:       m.Volume.ValueString(),
-					AutoCreate: false,
-					Increase:   false,
-					Size:       nil,
-				},
-				State: api.StatePresent,
+				Path:   m.Path.ValueString(),
+				Volume: buildMountVolumeInput(m, prevByKey[key]),
+				State:  api.StatePresent,
 			})
 		}
 	}
 	for _, m := range previousMounts {
-		key := fmt.Sprintf("%s|%s", m.Path.ValueString(), m.Volume.ValueString())
+		key := mountKey(m.Path.ValueString(), m.Volume.ValueString())
 		if _, exists := plannedMounts[key]; !exists {
 			input.Mounts = append(input.Mounts, api.MountInput{
 				Path: m.Path.ValueString(),
@@ -530,9 +712,52 @@ func (r *containerJobResource) Update(ctx context.Context, req resource.UpdateRe
 	// Environment variables
 	inputsUpd, dEnvU := buildEnvUpdateInputs(ctx, plan.EnvironmentVariables, prev.EnvironmentVariables)
 	resp.Diagnostics.Append(dEnvU...)
+	secretHashes, dEnvU := readSecretEnvHashes(ctx, req.Private)
+	resp.Diagnostics.Append(dEnvU...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	secretHashes = recordSecretEnvHashes(secretHashes, inputsUpd)
+	writeOnlyNames, dEnvU := writeOnlyEnvNames(ctx, req.Private)
+	resp.Diagnostics.Append(dEnvU...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Write-only values are only sent when their version changes, as the
+	// previous values cannot be compared with the configuration.
+	if !plan.SecretEnvWOVersion.Equal(prev.SecretEnvWOVersion) {
+		writeOnlyEnv, dEnvU := readWriteOnlyEnv(ctx, req.Config)
+		resp.Diagnostics.Append(dEnvU...)
+		writeOnlyInputs, dEnvU := writeOnlyEnvInputs(writeOnlyEnv, inputsUpd)
+		resp.Diagnostics.Append(dEnvU...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		inputsUpd = append(inputsUpd, writeOnlyInputs...)
+		for name := range writeOnlyNames {
+			if _, ok := writeOnlyEnv[name]; !ok {
+				inputsUpd = append(inputsUpd, api.EnvironmentVariableInput{Name: name, State: api.StateAbsent})
+			}
+		}
+		writeOnlyNames = map[string]bool{}
+		for name := range writeOnlyEnv {
+			writeOnlyNames[name] = true
+		}
+	}
+	// Variables injected by env_from are always resent so that changed
+	// connection details reach the container job.
+	envFromInputs, dEnvU := buildEnvFromInputs(ctx, r.nexaaClient.API, plan.EnvFrom, inputsUpd)
+	resp.Diagnostics.Append(dEnvU...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	inputsUpd = append(inputsUpd, envFromInputs...)
+	for name := range envFromNames(ctx, prev.EnvFrom) {
+		if !writeOnlyNames[name] && !envInputPresent(inputsUpd, name) {
+			inputsUpd = append(inputsUpd, api.EnvironmentVariableInput{Name: name, State: api.StateAbsent})
+		}
+	}
+	injectedNames := envFromNames(ctx, plan.EnvFrom)
 	if len(inputsUpd) > 0 {
 		input.EnvironmentVariables = inputsUpd
 	}
@@ -549,7 +774,7 @@ func (r *containerJobResource) Update(ctx context.Context, req resource.UpdateRe
 	defer cancel()
 
 	client := r.nexaaClient.API
-	err := waitForUnlocked(ctx, containerJobLocked(), client, plan.Namespace.ValueString(), plan.Name.ValueString())
+	err := waitForUnlocked(ctx, containerJobLocked(), client, r.nexaaClient.PollInterval, plan.Namespace.ValueString(), plan.Name.ValueString())
 
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating container job", "Could not reach an unlocked state: "+err.Error())
@@ -571,17 +796,18 @@ func (r *containerJobResource) Update(ctx context.Context, req resource.UpdateRe
 	plan.Enabled = types.BoolValue(containerJobResult.Enabled)
 	plan.State = prev.State
 
-	if containerJobResult.PrivateRegistry == nil {
-		plan.Registry = types.StringNull()
-	} else {
-		plan.Registry = types.StringValue(containerJobResult.PrivateRegistry.Name)
-	}
+	plan.Registry = processJobRegistryName(containerJobResult)
 
-	plan.Resources = types.StringValue(string(containerJobResult.Resources))
+	plan.Resources, diags = buildContainerResourcesFromApi(containerJobResult.Resources)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Environment variables (update state)
 	if containerJobResult.EnvironmentVariables != nil {
-		setVal, d := buildEnvSetFromAPI(ctx, containerJobResult.EnvironmentVariables, input.EnvironmentVariables, plan.EnvironmentVariables, secretUseProvided)
+		apiVars := withoutEnvNames(withoutEnvNames(containerJobResult.EnvironmentVariables, writeOnlyNames), injectedNames)
+		setVal, d := buildEnvSetFromAPI(ctx, apiVars, input.EnvironmentVariables, plan.EnvironmentVariables, secretUseProvided)
 		resp.Diagnostics.Append(d...)
 		if resp.Diagnostics.HasError() {
 			return
@@ -605,7 +831,7 @@ func (r *containerJobResource) Update(ctx context.Context, req resource.UpdateRe
 
 	// Mounts
 	if containerJobResult.Mounts != nil {
-		mountList, d := buildMountsFromApi(containerJobResult.Mounts)
+		mountList, d := buildMountsFromApi(ctx, containerJobResult.Mounts, plan.Mounts)
 		resp.Diagnostics.Append(d...)
 		if resp.Diagnostics.HasError() {
 			return
@@ -613,12 +839,21 @@ func (r *containerJobResource) Update(ctx context.Context, req resource.UpdateRe
 		plan.Mounts = mountList
 	}
 
+	resp.Diagnostics.Append(storeWriteOnlyEnvNames(ctx, resp.Private, writeOnlyNames)...)
+	resp.Diagnostics.Append(storeSecretEnvHashes(ctx, resp.Private, secretHashes)...)
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, containerIdentity{
+		Name:      plan.Name,
+		Namespace: plan.Namespace,
+	})...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *containerJobResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_container_job.Delete")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var plan containerJobResource
 	diags := req.State.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -638,7 +873,7 @@ func (r *containerJobResource) Delete(ctx context.Context, req resource.DeleteRe
 	defer cancel()
 
 	client := r.nexaaClient.API
-	err := waitForUnlocked(ctx, containerJobLocked(), client, plan.Namespace.ValueString(), plan.Name.ValueString())
+	err := waitForUnlocked(ctx, containerJobLocked(), client, r.nexaaClient.PollInterval, plan.Namespace.ValueString(), plan.Name.ValueString())
 
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting container job", "Could not reach an unlocked state: "+err.Error())
@@ -656,16 +891,11 @@ func (r *containerJobResource) Delete(ctx context.Context, req resource.DeleteRe
 }
 
 func (r *containerJobResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	parts := strings.SplitN(req.ID, "/", 2)
-	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
-		resp.Diagnostics.AddError(
-			"Invalid import ID",
-			"Expected import ID in the format \"<namespace>/<container_job_name>\", got: "+req.ID,
-		)
+	namespace, name, diags := containerJobImportTarget(ctx, req)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
-	namespace := parts[0]
-	name := parts[1]
 
 	// Fetch the container job from your API
 	client := r.nexaaClient.API
@@ -699,10 +929,16 @@ func (r *containerJobResource) ImportState(ctx context.Context, req resource.Imp
 		return
 	}
 
+	resourcesTF, diags := buildContainerResourcesFromApi(containerJob.Resources)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Mounts
 	mountTF := types.ListNull(MountsObjectType())
 	if containerJob.Mounts != nil {
-		mountList, d := buildMountsFromApi(containerJob.Mounts)
+		mountList, d := buildMountsFromApi(ctx, containerJob.Mounts, types.ListNull(MountsObjectType()))
 		resp.Diagnostics.Append(d...)
 		if resp.Diagnostics.HasError() {
 			return
@@ -710,19 +946,17 @@ func (r *containerJobResource) ImportState(ctx context.Context, req resource.Imp
 		mountTF = mountList
 	}
 
-	registryValue := types.StringNull()
-	if containerJob.PrivateRegistry != nil && containerJob.PrivateRegistry.Name != "public" {
-		registryValue = types.StringValue(containerJob.PrivateRegistry.Name)
-	}
-
 	state := containerJobResource{
 		ID:                   types.StringValue(containerJob.Name),
 		Name:                 types.StringValue(containerJob.Name),
 		Namespace:            types.StringValue(namespace),
 		Image:                types.StringValue(containerJob.Image),
-		Registry:             registryValue,
-		Resources:            types.StringValue(string(containerJob.Resources)),
+		Registry:             processJobRegistryName(containerJob),
+		Resources:            resourcesTF,
 		EnvironmentVariables: envTF,
+		SecretEnvWO:          types.MapNull(types.StringType),
+		SecretEnvWOVersion:   types.Int64Null(),
+		EnvFrom:              types.ListNull(envFromObjectType()),
 		Command:              commandList,
 		Entrypoint:           entrypointList,
 		Mounts:               mountTF,
@@ -747,4 +981,17 @@ func (r *containerJobResource) ImportState(ctx context.Context, req resource.Imp
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, containerIdentity{
+		Name:      state.Name,
+		Namespace: state.Namespace,
+	})...)
+}
+
+// processJobRegistryName returns the registry of a container job. Jobs that
+// pull from the public registry have no registry in state.
+func processJobRegistryName(containerJob api.ContainerJobResult) types.String {
+	if containerJob.PrivateRegistry == nil || containerJob.PrivateRegistry.Name == "public" {
+		return types.StringNull()
+	}
+	return types.StringValue(containerJob.PrivateRegistry.Name)
 }
@@ -22,6 +22,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/nexaa-cloud/nexaa-cli/api"
 	nexaaclient "github.com/nexaa-cloud/terraform-provider-nexaa/internal/client"
+	"github.com/nexaa-cloud/terraform-provider-nexaa/internal/tracing"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -29,6 +30,7 @@ var (
 	_ resource.Resource                = &messageQueueResource{}
 	_ resource.ResourceWithImportState = &messageQueueResource{}
 	_ resource.ResourceWithConfigure   = &messageQueueResource{}
+	_ resource.ResourceWithModifyPlan  = &messageQueueResource{}
 )
 
 // NewMessageQueueResource is a helper function to simplify the provider implementation.
@@ -82,6 +84,11 @@ func (r *messageQueueResource) Metadata(_ context.Context, req resource.Metadata
 	resp.TypeName = req.ProviderTypeName + "_message_queue"
 }
 
+// ModifyPlan fills in the namespace from the provider's default_namespace when it is omitted.
+func (r *messageQueueResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	applyDefaultNamespace(ctx, r.nexaaClient, req, resp)
+}
+
 // Schema defines the schema for the resource.
 func (r *messageQueueResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
@@ -92,8 +99,9 @@ func (r *messageQueueResource) Schema(ctx context.Context, _ resource.SchemaRequ
 				Computed:    true,
 			},
 			"namespace": schema.StringAttribute{
-				Description:   "Name of the namespace the message queue belongs to",
-				Required:      true,
+				Description:   "Name of the namespace the message queue belongs to, defaults to the default_namespace of the provider",
+				Optional:      true,
+				Computed:      true,
 				PlanModifiers: []planmodifier.String{ImmutableString()},
 			},
 			"name": schema.StringAttribute{
@@ -232,6 +240,9 @@ func (r *messageQueueResource) Schema(ctx context.Context, _ resource.SchemaRequ
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *messageQueueResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_message_queue.Create")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var plan messageQueueResource
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -290,7 +301,7 @@ func (r *messageQueueResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
-	err = waitForUnlocked(ctx, messageQueueLocked(), client, plan.Namespace.ValueString(), plan.Name.ValueString())
+	err = waitForUnlocked(ctx, messageQueueLocked(), client, r.nexaaClient.PollInterval, plan.Namespace.ValueString(), plan.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating message queue",
@@ -327,6 +338,9 @@ func (r *messageQueueResource) Create(ctx context.Context, req resource.CreateRe
 
 // Read refreshes the Terraform state with the latest data.
 func (r *messageQueueResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_message_queue.Read")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var state messageQueueResource
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -369,6 +383,9 @@ func (r *messageQueueResource) Read(ctx context.Context, req resource.ReadReques
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *messageQueueResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_message_queue.Update")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var plan messageQueueResource
 	var state messageQueueResource
 	diags := req.Plan.Get(ctx, &plan)
@@ -414,7 +431,7 @@ func (r *messageQueueResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	err = waitForUnlocked(ctx, messageQueueLocked(), client, plan.Namespace.ValueString(), plan.Name.ValueString())
+	err = waitForUnlocked(ctx, messageQueueLocked(), client, r.nexaaClient.PollInterval, plan.Namespace.ValueString(), plan.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error waiting for message queue to unlock",
@@ -451,6 +468,9 @@ func (r *messageQueueResource) Update(ctx context.Context, req resource.UpdateRe
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *messageQueueResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_message_queue.Delete")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var state messageQueueResource
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -470,7 +490,7 @@ func (r *messageQueueResource) Delete(ctx context.Context, req resource.DeleteRe
 	defer cancel()
 
 	client := r.nexaaClient.API
-	err := waitForUnlocked(ctx, messageQueueLocked(), client, state.Namespace.ValueString(), state.Name.ValueString())
+	err := waitForUnlocked(ctx, messageQueueLocked(), client, r.nexaaClient.PollInterval, state.Namespace.ValueString(), state.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error waiting for message queue to unlock",
@@ -5,9 +5,11 @@ package resources
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	nexaaclient "github.com/nexaa-cloud/terraform-provider-nexaa/internal/client"
+	"github.com/nexaa-cloud/terraform-provider-nexaa/internal/tracing"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/nexaa-cloud/nexaa-cli/api"
@@ -87,6 +89,9 @@ func (r *namespaceResource) Schema(_ context.Context, _ resource.SchemaRequest,
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *namespaceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_namespace.Create")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var plan namespaceResource
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -139,6 +144,9 @@ func (r *namespaceResource) Create(ctx context.Context, req resource.CreateReque
 
 // Read refreshes the Terraform state with the latest data.
 func (r *namespaceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_namespace.Read")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var state namespaceResource
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -173,6 +181,9 @@ func (r *namespaceResource) Read(ctx context.Context, req resource.ReadRequest,
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *namespaceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_namespace.Update")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var plan namespaceResource
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -189,6 +200,9 @@ func (r *namespaceResource) Update(ctx context.Context, req resource.UpdateReque
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *namespaceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_namespace.Delete")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var state namespaceResource
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -209,6 +223,17 @@ func (r *namespaceResource) Delete(ctx context.Context, req resource.DeleteReque
 
 	client := r.nexaaClient.API
 	namespaceName := state.Name.ValueString()
+
+	if r.nexaaClient.Features.PreventNamespaceDeletionIfContainsResources {
+		graceCtx, graceCancel := context.WithTimeout(ctx, namespaceDeletionGracePeriod)
+		err := waitForAllChildrenToBeRemoved(graceCtx, client, namespaceName)
+		graceCancel()
+		if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+			resp.Diagnostics.AddError("Error deleting namespace", namespaceContainsResourcesDetail(client, namespaceName))
+			return
+		}
+	}
+
 	err := waitForAllChildrenToBeRemoved(ctx, client, namespaceName)
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting namespace", err.Error())
@@ -19,6 +19,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/nexaa-cloud/nexaa-cli/api"
 	nexaaclient "github.com/nexaa-cloud/terraform-provider-nexaa/internal/client"
+	"github.com/nexaa-cloud/terraform-provider-nexaa/internal/tracing"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -26,6 +27,7 @@ var (
 	_ resource.Resource                = &registryResource{}
 	_ resource.ResourceWithImportState = &registryResource{}
 	_ resource.ResourceWithConfigure   = &registryResource{}
+	_ resource.ResourceWithModifyPlan  = &registryResource{}
 )
 
 // NewRegistryResource is a helper function to simplify the provider implementation.
@@ -66,6 +68,11 @@ func (r *registryResource) Metadata(_ context.Context, req resource.MetadataRequ
 	resp.TypeName = req.ProviderTypeName + "_registry"
 }
 
+// ModifyPlan fills in the namespace from the provider's default_namespace when it is omitted.
+func (r *registryResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	applyDefaultNamespace(ctx, r.nexaaClient, req, resp)
+}
+
 // Schema defines the schema for the resource.
 func (r *registryResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
@@ -75,8 +82,9 @@ func (r *registryResource) Schema(ctx context.Context, _ resource.SchemaRequest,
 				Computed:    true,
 			},
 			"namespace": schema.StringAttribute{
-				Description: "Name of the namespace the private registry belongs to",
-				Required:    true,
+				Description: "Name of the namespace the private registry belongs to, defaults to the default_namespace of the provider",
+				Optional:    true,
+				Computed:    true,
 			},
 			"name": schema.StringAttribute{
 				Description: "The name given to the private registry",
@@ -124,6 +132,9 @@ func (r *registryResource) Schema(ctx context.Context, _ resource.SchemaRequest,
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *registryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_registry.Create")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var plan registryResource
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -194,6 +205,9 @@ func (r *registryResource) Create(ctx context.Context, req resource.CreateReques
 
 // Read refreshes the Terraform state with the latest data.
 func (r *registryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_registry.Read")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var state registryResource
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -238,6 +252,9 @@ func (r *registryResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *registryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_registry.Update")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var plan registryResource
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -254,6 +271,9 @@ func (r *registryResource) Update(ctx context.Context, req resource.UpdateReques
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *registryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_registry.Delete")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var state registryResource
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -272,7 +292,7 @@ func (r *registryResource) Delete(ctx context.Context, req resource.DeleteReques
 
 	client := r.nexaaClient.API
 
-	err := waitForUnlocked(ctx, registryLocked(), client, state.Namespace.ValueString(), state.Name.ValueString())
+	err := waitForUnlocked(ctx, registryLocked(), client, r.nexaaClient.PollInterval, state.Namespace.ValueString(), state.Name.ValueString())
 
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting registry", "Could not reach an unlocked state: "+err.Error())
@@ -10,19 +10,25 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 
 	nexaaclient "github.com/nexaa-cloud/terraform-provider-nexaa/internal/client"
+	"github.com/nexaa-cloud/terraform-provider-nexaa/internal/tracing"
 
 	"github.com/nexaa-cloud/nexaa-cli/api"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -34,6 +40,7 @@ var (
 	_ resource.ResourceWithImportState = &starterContainerResource{}
 	_ resource.ResourceWithIdentity    = &starterContainerResource{}
 	_ resource.ResourceWithConfigure   = &starterContainerResource{}
+	_ resource.ResourceWithModifyPlan  = &starterContainerResource{}
 )
 
 // NewStarterContainerResource is a helper function to simplify the provider implementation.
@@ -65,12 +72,17 @@ type starterContainerResource struct {
 	Command              types.List     `tfsdk:"command"`
 	Entrypoint           types.List     `tfsdk:"entrypoint"`
 	EnvironmentVariables types.Set      `tfsdk:"environment_variables"`
+	SecretEnvWO          types.Map      `tfsdk:"secret_environment_variables_wo"`
+	SecretEnvWOVersion   types.Int64    `tfsdk:"secret_environment_variables_wo_version"`
+	EnvFrom              types.List     `tfsdk:"env_from"`
 	Ports                types.List     `tfsdk:"ports"`
 	Ingresses            types.List     `tfsdk:"ingresses"`
 	ExternalConnection   types.Object   `tfsdk:"external_connection"`
 	Mounts               types.List     `tfsdk:"mounts"`
 	HealthCheck          types.Object   `tfsdk:"health_check"`
 	Status               types.String   `tfsdk:"status"`
+	WaitForReady         types.Bool     `tfsdk:"wait_for_ready"`
+	RedeployTrigger      types.Map      `tfsdk:"redeploy_trigger"`
 	Timeouts             timeouts.Value `tfsdk:"timeouts"`
 }
 
@@ -79,6 +91,23 @@ func (r *starterContainerResource) Metadata(_ context.Context, req resource.Meta
 	resp.TypeName = req.ProviderTypeName + "_starter_container"
 }
 
+// ModifyPlan fills in the namespace from the provider's default_namespace when it is omitted
+// and checks that the ingress ports are exposed.
+func (r *starterContainerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	applyDefaultNamespace(ctx, r.nexaaClient, req, resp)
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var ports, ingresses types.List
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("ports"), &ports)...)
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("ingresses"), &ingresses)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(validateIngressPortsPlan(ctx, ports, ingresses)...)
+}
+
 func (r *starterContainerResource) IdentitySchema(ctx context.Context, request resource.IdentitySchemaRequest, response *resource.IdentitySchemaResponse) {
 	response.IdentitySchema = identityschema.Schema{
 		Attributes: map[string]identityschema.Attribute{
@@ -113,8 +142,9 @@ func (r *starterContainerResource) Schema(ctx context.Context, _ resource.Schema
 				},
 			},
 			"namespace": schema.StringAttribute{
-				Required:    true,
-				Description: "Name of the namespace that the container will belong to",
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of the namespace that the container will belong to, defaults to the default_namespace of the provider",
 			},
 			"image": schema.StringAttribute{
 				Required:    true,
@@ -127,20 +157,21 @@ func (r *starterContainerResource) Schema(ctx context.Context, _ resource.Schema
 			"command": schema.ListAttribute{
 				ElementType: types.StringType,
 				Optional:    true,
-				Computed:    true,
-				Description: "Command to run. When the field is omitted, the default command of the image will be used. The command will be passed to the entrypoint as arguments. Environment variables can be used in the command by using the syntax $(ENVIRONMENT_VARIABLE).",
+				Description: "Command to run. When the field is omitted, the default command of the image will be used. An empty list is sent as an empty command. The command will be passed to the entrypoint as arguments. Environment variables can be used in the command by using the syntax $(ENVIRONMENT_VARIABLE).",
 			},
 			"entrypoint": schema.ListAttribute{
 				ElementType: types.StringType,
 				Optional:    true,
-				Computed:    true,
-				Description: "Entrypoint of the container. This field will overwrite the default entrypoint of the image. When the field is omitted, the default entrypoint of the image will be used. Entry point is the first command executed when the container starts. It will receive the command as arguments.",
+				Description: "Entrypoint of the container. This field will overwrite the default entrypoint of the image. When the field is omitted, the default entrypoint of the image will be used. An empty list is sent as an empty entrypoint. Entry point is the first command executed when the container starts. It will receive the command as arguments.",
 			},
 			"ports": schema.ListAttribute{
 				ElementType: types.StringType,
 				Optional:    true,
 				Computed:    true,
 				Description: "The ports used to expose for traffic, format as from:to",
+				Validators: []validator.List{
+					portMappingsValidator{},
+				},
 			},
 			"environment_variables": schema.SetNestedAttribute{
 				NestedObject: schema.NestedAttributeObject{
@@ -166,6 +197,33 @@ func (r *starterContainerResource) Schema(ctx context.Context, _ resource.Schema
 				Computed:    true,
 				Description: "Environment variables used in the container; order is not significant and matched by name",
 			},
+			"secret_environment_variables_wo": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				WriteOnly:   true,
+				Sensitive:   true,
+				Description: "Secret environment variables whose values are never stored in the Terraform state or plan, keyed by name. The values are only sent when secret_environment_variables_wo_version changes. Requires Terraform 1.11 or later.",
+			},
+			"secret_environment_variables_wo_version": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Version of secret_environment_variables_wo. Change it to send new values of the write-only environment variables to the API.",
+			},
+			"env_from": schema.ListNestedAttribute{
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"cloud_database_cluster_user": schema.StringAttribute{
+							Required:    true,
+							Description: "The id of the nexaa_cloud_database_cluster_user whose connection details are injected",
+						},
+						"prefix": schema.StringAttribute{
+							Optional:    true,
+							Description: "Prefix of the injected HOST, PORT, USER and PASSWORD variables, defaults to DATABASE_",
+						},
+					},
+				},
+				Optional:    true,
+				Description: "Injects the connection details of a cloud database cluster user as environment variables. The password is read from the credentials of the user and injected as a secret variable. The injected variables are not part of environment_variables",
+			},
 			"ingresses": schema.ListNestedAttribute{
 				Validators: []validator.List{
 					noDuplicateDefaultIngressValidator{},
@@ -175,7 +233,7 @@ func (r *starterContainerResource) Schema(ctx context.Context, _ resource.Schema
 						"domain_name": schema.StringAttribute{
 							Optional:    true,
 							Computed:    true,
-							Description: "The domain used for the ingress, defaults to https://{tenant}-{namespaceName}-{containerName}.container.tilaa.cloud",
+							Description: "The domain used for the ingress, defaults to https://{tenant}-{namespaceName}-{containerName}.container.tilaa.cloud. The configured spelling is kept in state when the API normalizes it",
 							PlanModifiers: []planmodifier.String{
 								stringplanmodifier.UseStateForUnknown(),
 							},
@@ -188,22 +246,23 @@ func (r *starterContainerResource) Schema(ctx context.Context, _ resource.Schema
 							Required:    true,
 							Description: "Boolean representing if you want TLS enabled or not",
 						},
-						"allowlist": schema.ListAttribute{
+						"allowlist": schema.SetAttribute{
 							ElementType: types.StringType,
 							Optional:    true,
 							Computed:    true,
-							Description: "A list with the IP's that can access the database cluster through the external connection, can be in ipv4 and/or ipv6 format. Defaults to 0.0.0.0/0 and ::/0, which means that the starter container can be accessed from any IP address.",
-							Default: listdefault.StaticValue(
-								types.ListValueMust(types.StringType, []attr.Value{
+							Description: "A set with the IP's or CIDR ranges that can access the ingress url, can be in ipv4 and/or ipv6 format. Defaults to 0.0.0.0/0 and ::/0, which means that the starter container can be accessed from any IP address.",
+							Default: setdefault.StaticValue(
+								types.SetValueMust(types.StringType, []attr.Value{
 									types.StringValue("0.0.0.0/0"),
 									types.StringValue("::/0"),
 								}),
 							),
-							PlanModifiers: []planmodifier.List{
-								listplanmodifier.UseStateForUnknown(),
+							PlanModifiers: []planmodifier.Set{
+								setplanmodifier.UseStateForUnknown(),
 							},
-							Validators: []validator.List{
+							Validators: []validator.Set{
 								noEmptyAllowlistValidator{},
+								setvalidator.ValueStringsAre(cidrValidator{}),
 							},
 						},
 					},
@@ -278,6 +337,16 @@ func (r *starterContainerResource) Schema(ctx context.Context, _ resource.Schema
 							Required:    true,
 							Description: "The name of the volume that is used for the mount",
 						},
+						"auto_create": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(false),
+							Description: "Create the volume when it does not exist yet. The created volume is not managed by Terraform and is kept when the mount is removed",
+						},
+						"size": schema.Int64Attribute{
+							Optional:    true,
+							Description: "Size of the volume in GB, min 1GB/ max 100GB. Used when the volume is auto created, a larger size increases an existing volume",
+						},
 					},
 				},
 				Computed:    true,
@@ -307,10 +376,22 @@ func (r *starterContainerResource) Schema(ctx context.Context, _ resource.Schema
 				},
 				Computed: true,
 			},
+			"wait_for_ready": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Wait on create and update until the starter container is unlocked and all its replicas are available, failing with the last state of the starter container when it does not get there in time. The wait is bounded by the create and update timeouts, so raise those when enabling this",
+			},
+			"redeploy_trigger": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Arbitrary values that redeploy the starter container when they change, for example to pull a mutable tag like latest again. The API has no restart operation, so a change sends the configuration of the starter container again in an in-place update",
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"timeouts": timeouts.Block(ctx, timeouts.Opts{
 				Create: true,
+				Read:   true,
 				Update: true,
 				Delete: true,
 			}),
@@ -320,6 +401,9 @@ func (r *starterContainerResource) Schema(ctx context.Context, _ resource.Schema
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *starterContainerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_starter_container.Create")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var plan starterContainerResource
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -353,7 +437,7 @@ func (r *starterContainerResource) Create(ctx context.Context, req resource.Crea
 	}
 
 	// Command
-	command, diags := buildCommandInput(ctx, plan.Command)
+	command, diags := buildCommandResetInput(ctx, plan.Command)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -361,7 +445,7 @@ func (r *starterContainerResource) Create(ctx context.Context, req resource.Crea
 	input.Command = command
 
 	// Entrypoint
-	entrypoint, diags := buildEntrypointInput(ctx, plan.Entrypoint)
+	entrypoint, diags := buildCommandResetInput(ctx, plan.Entrypoint)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -398,15 +482,51 @@ func (r *starterContainerResource) Create(ctx context.Context, req resource.Crea
 	}
 	input.ExternalConnection = externalConnInput
 
+	// Refuse to take over a container that already exists
+	r.nexaaClient.Lock("container:" + plan.Namespace.ValueString() + "/" + plan.Name.ValueString())
+	defer r.nexaaClient.Unlock("container:" + plan.Namespace.ValueString() + "/" + plan.Name.ValueString())
+
+	client := r.nexaaClient.API
+	if _, checkErr := client.ListContainerByName(plan.Namespace.ValueString(), plan.Name.ValueString()); checkErr == nil {
+		resp.Diagnostics.AddError("Container already exists",
+			"A container named "+plan.Name.ValueString()+" already exists in namespace "+plan.Namespace.ValueString()+". "+
+				"To manage it with Terraform use: terraform import nexaa_starter_container.example "+plan.Namespace.ValueString()+"/"+plan.Name.ValueString())
+		return
+	} else if !isNotFoundErr(checkErr) {
+		resp.Diagnostics.AddError("Error checking for existing container",
+			"Could not verify name availability: "+checkErr.Error())
+		return
+	}
+
 	// Environment variables (build API input from plan)
 	inputs, dEnv := extractEnvInputsFromSet(ctx, plan.EnvironmentVariables)
 	resp.Diagnostics.Append(dEnv...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	secretHashes := recordSecretEnvHashes(newSecretEnvHashes(), inputs)
+	writeOnlyEnv, dEnv := readWriteOnlyEnv(ctx, req.Config)
+	resp.Diagnostics.Append(dEnv...)
+	writeOnlyInputs, dEnv := writeOnlyEnvInputs(writeOnlyEnv, inputs)
+	resp.Diagnostics.Append(dEnv...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	inputs = append(inputs, writeOnlyInputs...)
+	envFromInputs, dEnv := buildEnvFromInputs(ctx, r.nexaaClient.API, plan.EnvFrom, inputs)
+	resp.Diagnostics.Append(dEnv...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	inputs = append(inputs, envFromInputs...)
 	if len(inputs) > 0 {
 		input.EnvironmentVariables = inputs
 	}
+	writeOnlyNames := map[string]bool{}
+	for name := range writeOnlyEnv {
+		writeOnlyNames[name] = true
+	}
+	injectedNames := envFromNames(ctx, plan.EnvFrom)
 
 	// Health check
 	healthCheck, diags := buildHealthCheckInput(ctx, plan.HealthCheck)
@@ -417,21 +537,6 @@ func (r *starterContainerResource) Create(ctx context.Context, req resource.Crea
 	input.HealthCheck = healthCheck
 
 	// Create containerResult
-	r.nexaaClient.Lock("container:" + plan.Namespace.ValueString() + "/" + plan.Name.ValueString())
-	defer r.nexaaClient.Unlock("container:" + plan.Namespace.ValueString() + "/" + plan.Name.ValueString())
-
-	client := r.nexaaClient.API
-	if _, checkErr := client.ListContainerByName(plan.Namespace.ValueString(), plan.Name.ValueString()); checkErr == nil {
-		resp.Diagnostics.AddError("Container already exists",
-			"A container named "+plan.Name.ValueString()+" already exists in namespace "+plan.Namespace.ValueString()+". "+
-				"To manage it with Terraform use: terraform import nexaa_starter_container.example "+plan.Namespace.ValueString()+"/"+plan.Name.ValueString())
-		return
-	} else if !isNotFoundErr(checkErr) {
-		resp.Diagnostics.AddError("Error checking for existing container",
-			"Could not verify name availability: "+checkErr.Error())
-		return
-	}
-
 	containerResult, err := client.ContainerCreate(input)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating starter container", "Could not create starter container: "+err.Error())
@@ -448,22 +553,25 @@ func (r *starterContainerResource) Create(ctx context.Context, req resource.Crea
 	plan.Registry = processRegistryName(containerResult)
 
 	// Command
-	plan.Command, diags = buildCommandState(containerResult.Command)
+	commandState, diags := buildCommandState(containerResult.Command)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	plan.Command = keepEmptyList(commandState, plan.Command)
 
 	// Entrypoint
-	plan.Entrypoint, diags = buildEntrypointState(containerResult.Entrypoint)
+	entrypointState, diags := buildEntrypointState(containerResult.Entrypoint)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	plan.Entrypoint = keepEmptyList(entrypointState, plan.Entrypoint)
 
 	// Environment variables (state population)
 	if containerResult.EnvironmentVariables != nil {
-		setVal, d := buildEnvSetFromAPI(ctx, containerResult.EnvironmentVariables, input.EnvironmentVariables, types.SetNull(envVarObjectType()), secretUseProvided)
+		apiVars := withoutEnvNames(withoutEnvNames(containerResult.EnvironmentVariables, writeOnlyNames), injectedNames)
+		setVal, d := buildEnvSetFromAPI(ctx, apiVars, input.EnvironmentVariables, types.SetNull(envVarObjectType()), secretUseProvided)
 		resp.Diagnostics.Append(d...)
 		if resp.Diagnostics.HasError() {
 			return
@@ -481,7 +589,7 @@ func (r *starterContainerResource) Create(ctx context.Context, req resource.Crea
 
 	// Mounts
 	if containerResult.Mounts != nil {
-		mountList, d := buildMountsFromApi(containerResult.Mounts)
+		mountList, d := buildMountsFromApi(ctx, containerResult.Mounts, plan.Mounts)
 		resp.Diagnostics.Append(d...)
 		if resp.Diagnostics.HasError() {
 			return
@@ -490,7 +598,7 @@ func (r *starterContainerResource) Create(ctx context.Context, req resource.Crea
 	}
 
 	// Ingresses
-	ingressesList, d := buildIngressesFromApi(containerResult)
+	ingressesList, d := buildIngressesFromApiInPlanOrder(ctx, containerResult, plan.Ingresses)
 	resp.Diagnostics.Append(d...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -508,6 +616,20 @@ func (r *starterContainerResource) Create(ctx context.Context, req resource.Crea
 	// Health check
 	plan.HealthCheck = buildHealthCheckState(containerResult)
 
+	// The starter container exists from here on, so it is saved in state even
+	// when it does not become ready; Terraform then marks it tainted.
+	if plan.WaitForReady.ValueBool() {
+		state, err := waitForContainerReady(ctx, client, r.nexaaClient.PollInterval, plan.Namespace.ValueString(), plan.Name.ValueString())
+		if state != "" {
+			plan.Status = types.StringValue(state)
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("Starter container is not ready", err.Error())
+		}
+	}
+
+	resp.Diagnostics.Append(storeWriteOnlyEnvNames(ctx, resp.Private, writeOnlyNames)...)
+	resp.Diagnostics.Append(storeSecretEnvHashes(ctx, resp.Private, secretHashes)...)
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -527,6 +649,9 @@ func (r *starterContainerResource) Create(ctx context.Context, req resource.Crea
 
 // Read refreshes the Terraform state with the latest data.
 func (r *starterContainerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_starter_container.Read")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var state starterContainerResource
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -534,9 +659,20 @@ func (r *starterContainerResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, 30*time.Second)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// Fetch the created container
 	client := r.nexaaClient.API
-	container, err := client.ListContainerByName(state.Namespace.ValueString(), state.Name.ValueString())
+	container, err := callWithContext(ctx, func() (api.ContainerResult, error) {
+		return client.ListContainerByName(state.Namespace.ValueString(), state.Name.ValueString())
+	})
 	if err != nil {
 		if isNotFoundErr(err) {
 			resp.State.RemoveResource(ctx)
@@ -555,22 +691,37 @@ func (r *starterContainerResource) Read(ctx context.Context, req resource.ReadRe
 	state.Registry = processRegistryName(container)
 
 	// Command
-	state.Command, diags = buildCommandState(container.Command)
+	commandState, diags := buildCommandState(container.Command)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	state.Command = keepEmptyList(commandState, state.Command)
 
 	// Entrypoint
-	state.Entrypoint, diags = buildEntrypointState(container.Entrypoint)
+	entrypointState, diags := buildEntrypointState(container.Entrypoint)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	state.Entrypoint = keepEmptyList(entrypointState, state.Entrypoint)
 
 	// Environment variables (refresh state)
+	writeOnlyNames, diags := writeOnlyEnvNames(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 	if container.EnvironmentVariables != nil {
-		setVal, d := buildEnvSetFromAPI(ctx, container.EnvironmentVariables, nil, state.EnvironmentVariables, secretPreservePrev)
+		apiVars := withoutEnvNames(withoutEnvNames(container.EnvironmentVariables, writeOnlyNames), envFromNames(ctx, state.EnvFrom))
+		setVal, d := buildEnvSetFromAPI(ctx, apiVars, nil, state.EnvironmentVariables, secretPreservePrev)
+		resp.Diagnostics.Append(d...)
+		secretHashes, d := readSecretEnvHashes(ctx, req.Private)
+		resp.Diagnostics.Append(d...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		setVal, d = maskStaleSecrets(ctx, setVal, secretHashes)
 		resp.Diagnostics.Append(d...)
 		if resp.Diagnostics.HasError() {
 			return
@@ -588,7 +739,7 @@ func (r *starterContainerResource) Read(ctx context.Context, req resource.ReadRe
 
 	// Mounts
 	if container.Mounts != nil {
-		mountList, d := buildMountsFromApi(container.Mounts)
+		mountList, d := buildMountsFromApi(ctx, container.Mounts, state.Mounts)
 		resp.Diagnostics.Append(d...)
 		if resp.Diagnostics.HasError() {
 			return
@@ -616,6 +767,9 @@ func (r *starterContainerResource) Read(ctx context.Context, req resource.ReadRe
 	state.HealthCheck = buildHealthCheckState(container)
 
 	state.Status = types.StringValue(container.State)
+	if state.WaitForReady.IsNull() {
+		state.WaitForReady = types.BoolValue(false)
+	}
 
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
@@ -636,6 +790,9 @@ func (r *starterContainerResource) Read(ctx context.Context, req resource.ReadRe
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *starterContainerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_starter_container.Update")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var plan starterContainerResource
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -658,25 +815,21 @@ func (r *starterContainerResource) Update(ctx context.Context, req resource.Upda
 		Resources: &resources,
 	}
 
-	// Command - only set if provided (following CLI modify behavior)
-	command, shouldUpdateCmd, diags := buildCommandUpdateInput(ctx, plan.Command)
+	// Command - a removed command is sent as null to reset it to the image default
+	command, diags := buildCommandResetInput(ctx, plan.Command)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	if shouldUpdateCmd {
-		input.Command = command
-	}
+	input.Command = command
 
-	// Entrypoint - only set if provided (following CLI modify behavior)
-	entrypoint, shouldUpdateEp, diags := buildEntrypointUpdateInput(ctx, plan.Entrypoint)
+	// Entrypoint - a removed entrypoint is sent as null to reset it to the image default
+	entrypoint, diags := buildCommandResetInput(ctx, plan.Entrypoint)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	if shouldUpdateEp {
-		input.Entrypoint = entrypoint
-	}
+	input.Entrypoint = entrypoint
 
 	// Use common functions for input building
 	ports, diags := buildPortsInput(ctx, plan.Ports)
@@ -723,9 +876,52 @@ func (r *starterContainerResource) Update(ctx context.Context, req resource.Upda
 	// Environment variables
 	inputsUpd, dEnvU := buildEnvUpdateInputs(ctx, plan.EnvironmentVariables, prev.EnvironmentVariables)
 	resp.Diagnostics.Append(dEnvU...)
+	secretHashes, dEnvU := readSecretEnvHashes(ctx, req.Private)
+	resp.Diagnostics.Append(dEnvU...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	secretHashes = recordSecretEnvHashes(secretHashes, inputsUpd)
+	writeOnlyNames, dEnvU := writeOnlyEnvNames(ctx, req.Private)
+	resp.Diagnostics.Append(dEnvU...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Write-only values are only sent when their version changes, as the
+	// previous values cannot be compared with the configuration.
+	if !plan.SecretEnvWOVersion.Equal(prev.SecretEnvWOVersion) {
+		writeOnlyEnv, dEnvU := readWriteOnlyEnv(ctx, req.Config)
+		resp.Diagnostics.Append(dEnvU...)
+		writeOnlyInputs, dEnvU := writeOnlyEnvInputs(writeOnlyEnv, inputsUpd)
+		resp.Diagnostics.Append(dEnvU...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		inputsUpd = append(inputsUpd, writeOnlyInputs...)
+		for name := range writeOnlyNames {
+			if _, ok := writeOnlyEnv[name]; !ok {
+				inputsUpd = append(inputsUpd, api.EnvironmentVariableInput{Name: name, State: api.StateAbsent})
+			}
+		}
+		writeOnlyNames = map[string]bool{}
+		for name := range writeOnlyEnv {
+			writeOnlyNames[name] = true
+		}
+	}
+	// Variables injected by env_from are always resent so that changed
+	// connection details reach the container.
+	envFromInputs, dEnvU := buildEnvFromInputs(ctx, r.nexaaClient.API, plan.EnvFrom, inputsUpd)
+	resp.Diagnostics.Append(dEnvU...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	inputsUpd = append(inputsUpd, envFromInputs...)
+	for name := range envFromNames(ctx, prev.EnvFrom) {
+		if !writeOnlyNames[name] && !envInputPresent(inputsUpd, name) {
+			inputsUpd = append(inputsUpd, api.EnvironmentVariableInput{Name: name, State: api.StateAbsent})
+		}
+	}
+	injectedNames := envFromNames(ctx, plan.EnvFrom)
 	if len(inputsUpd) > 0 {
 		input.EnvironmentVariables = inputsUpd
 	}
@@ -750,7 +946,7 @@ func (r *starterContainerResource) Update(ctx context.Context, req resource.Upda
 	defer cancel()
 
 	client := r.nexaaClient.API
-	err := waitForUnlocked(ctx, containerLocked(), client, plan.Namespace.ValueString(), plan.Name.ValueString())
+	err := waitForUnlocked(ctx, containerLocked(), client, r.nexaaClient.PollInterval, plan.Namespace.ValueString(), plan.Name.ValueString())
 
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating starter container", "Could not reach a running state: "+err.Error())
@@ -758,13 +954,15 @@ func (r *starterContainerResource) Update(ctx context.Context, req resource.Upda
 	}
 
 	// modify containerResult
+	// The whole configuration is sent on every update, so a change to only
+	// redeploy_trigger still reaches the API and redeploys the starter container.
 	containerResult, err := client.ContainerModify(input)
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating starter container", "Could not update starter container: "+err.Error())
 		return
 	}
 
-	err = waitForUnlocked(ctx, containerLocked(), client, plan.Namespace.ValueString(), plan.Name.ValueString())
+	err = waitForUnlocked(ctx, containerLocked(), client, r.nexaaClient.PollInterval, plan.Namespace.ValueString(), plan.Name.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error updating starter container", "Could not reach a running state: "+err.Error())
 		return
@@ -785,22 +983,25 @@ func (r *starterContainerResource) Update(ctx context.Context, req resource.Upda
 	plan.Registry = processRegistryName(containerResult)
 
 	// Command
-	plan.Command, diags = buildCommandState(containerResult.Command)
+	commandState, diags := buildCommandState(containerResult.Command)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	plan.Command = keepEmptyList(commandState, plan.Command)
 
 	// Entrypoint
-	plan.Entrypoint, diags = buildEntrypointState(containerResult.Entrypoint)
+	entrypointState, diags := buildEntrypointState(containerResult.Entrypoint)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	plan.Entrypoint = keepEmptyList(entrypointState, plan.Entrypoint)
 
 	// Environment variables (update state)
 	if containerResult.EnvironmentVariables != nil {
-		setVal, d := buildEnvSetFromAPI(ctx, containerResult.EnvironmentVariables, input.EnvironmentVariables, plan.EnvironmentVariables, secretUseProvided)
+		apiVars := withoutEnvNames(withoutEnvNames(containerResult.EnvironmentVariables, writeOnlyNames), injectedNames)
+		setVal, d := buildEnvSetFromAPI(ctx, apiVars, input.EnvironmentVariables, plan.EnvironmentVariables, secretUseProvided)
 		resp.Diagnostics.Append(d...)
 		if resp.Diagnostics.HasError() {
 			return
@@ -818,7 +1019,7 @@ func (r *starterContainerResource) Update(ctx context.Context, req resource.Upda
 
 	// Mounts
 	if containerResult.Mounts != nil {
-		mountList, d := buildMountsFromApi(containerResult.Mounts)
+		mountList, d := buildMountsFromApi(ctx, containerResult.Mounts, plan.Mounts)
 		resp.Diagnostics.Append(d...)
 		if resp.Diagnostics.HasError() {
 			return
@@ -847,6 +1048,14 @@ func (r *starterContainerResource) Update(ctx context.Context, req resource.Upda
 
 	plan.Status = prev.Status
 
+	if plan.WaitForReady.ValueBool() {
+		if _, err := waitForContainerReady(ctx, client, r.nexaaClient.PollInterval, plan.Namespace.ValueString(), plan.Name.ValueString()); err != nil {
+			resp.Diagnostics.AddError("Starter container is not ready", err.Error())
+		}
+	}
+
+	resp.Diagnostics.Append(storeWriteOnlyEnvNames(ctx, resp.Private, writeOnlyNames)...)
+	resp.Diagnostics.Append(storeSecretEnvHashes(ctx, resp.Private, secretHashes)...)
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -866,6 +1075,9 @@ func (r *starterContainerResource) Update(ctx context.Context, req resource.Upda
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *starterContainerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_starter_container.Delete")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var plan starterContainerResource
 	diags := req.State.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -885,15 +1097,19 @@ func (r *starterContainerResource) Delete(ctx context.Context, req resource.Dele
 	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
 	defer cancel()
 
-	err := waitForUnlocked(ctx, containerLocked(), client, plan.Namespace.ValueString(), plan.Name.ValueString())
+	err := waitForUnlocked(ctx, containerLocked(), client, r.nexaaClient.PollInterval, plan.Namespace.ValueString(), plan.Name.ValueString())
 
+	// A starter container deleted outside of Terraform is already gone.
+	if isNotFoundErr(err) {
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting starter container", "Could not reach an unlocked state: "+err.Error())
 		return
 	}
 
 	_, err = client.ContainerDelete(plan.Namespace.ValueString(), plan.Name.ValueString())
-	if err != nil {
+	if err != nil && !isNotFoundErr(err) {
 		resp.Diagnostics.AddError(
 			"Error deleting starter container",
 			fmt.Sprintf("Failed to delete starter container %q: %s", plan.Name.ValueString(), err.Error()),
@@ -903,9 +1119,9 @@ func (r *starterContainerResource) Delete(ctx context.Context, req resource.Dele
 }
 
 func (r *starterContainerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	namespace, name, err := parseContainerImportID(req.ID)
-	if err != nil {
-		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+	namespace, name, diags := containerImportTarget(ctx, req)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
@@ -932,11 +1148,13 @@ func (r *starterContainerResource) ImportState(ctx context.Context, req resource
 		Object: types.ObjectValueMust(
 			map[string]attr.Type{
 				"create": types.StringType,
+				"read":   types.StringType,
 				"update": types.StringType,
 				"delete": types.StringType,
 			},
 			map[string]attr.Value{
 				"create": types.StringValue("30s"),
+				"read":   types.StringValue("30s"),
 				"update": types.StringValue("30s"),
 				"delete": types.StringValue("2m"),
 			},
@@ -953,15 +1171,24 @@ func (r *starterContainerResource) ImportState(ctx context.Context, req resource
 		Command:              stateAttrs["command"].(types.List),
 		Entrypoint:           stateAttrs["entrypoint"].(types.List),
 		EnvironmentVariables: stateAttrs["environment_variables"].(types.Set),
+		SecretEnvWO:          types.MapNull(types.StringType),
+		SecretEnvWOVersion:   types.Int64Null(),
+		EnvFrom:              types.ListNull(envFromObjectType()),
 		Ports:                stateAttrs["ports"].(types.List),
 		Ingresses:            stateAttrs["ingresses"].(types.List),
 		ExternalConnection:   stateAttrs["external_connection"].(types.Object),
 		Mounts:               stateAttrs["mounts"].(types.List),
 		HealthCheck:          stateAttrs["health_check"].(types.Object),
 		Status:               stateAttrs["status"].(types.String),
+		WaitForReady:         types.BoolValue(false),
+		RedeployTrigger:      types.MapNull(types.StringType),
 
 		Timeouts: stateAttrs["timeouts"].(timeouts.Value),
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	resp.Diagnostics.Append(resp.Identity.Set(ctx, containerIdentity{
+		Name:      types.StringValue(name),
+		Namespace: types.StringValue(namespace),
+	})...)
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/nexaa-cloud/nexaa-cli/api"
 	nexaaclient "github.com/nexaa-cloud/terraform-provider-nexaa/internal/client"
+	"github.com/nexaa-cloud/terraform-provider-nexaa/internal/tracing"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -23,6 +24,7 @@ var (
 	_ resource.Resource                = &volumeResource{}
 	_ resource.ResourceWithImportState = &volumeResource{}
 	_ resource.ResourceWithConfigure   = &volumeResource{}
+	_ resource.ResourceWithModifyPlan  = &volumeResource{}
 )
 
 // NewVolumeResource is a helper function to simplify the provider implementation.
@@ -61,6 +63,11 @@ func (r *volumeResource) Metadata(_ context.Context, req resource.MetadataReques
 	resp.TypeName = req.ProviderTypeName + "_volume"
 }
 
+// ModifyPlan fills in the namespace from the provider's default_namespace when it is omitted.
+func (r *volumeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	applyDefaultNamespace(ctx, r.nexaaClient, req, resp)
+}
+
 // Schema defines the schema for the resource.
 func (r *volumeResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
@@ -70,8 +77,9 @@ func (r *volumeResource) Schema(ctx context.Context, _ resource.SchemaRequest, r
 				Computed:    true,
 			},
 			"namespace": schema.StringAttribute{
-				Description: "Name of the namespace where the volume is located",
-				Required:    true,
+				Description: "Name of the namespace where the volume is located, defaults to the default_namespace of the provider",
+				Optional:    true,
+				Computed:    true,
 			},
 			"name": schema.StringAttribute{
 				Description: "Name of the volume",
@@ -107,6 +115,9 @@ func (r *volumeResource) Schema(ctx context.Context, _ resource.SchemaRequest, r
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *volumeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_volume.Create")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var plan volumeResource
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -156,6 +167,9 @@ func (r *volumeResource) Create(ctx context.Context, req resource.CreateRequest,
 
 // Read refreshes the Terraform state with the latest data.
 func (r *volumeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_volume.Read")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var state volumeResource
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -194,6 +208,9 @@ func (r *volumeResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *volumeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_volume.Update")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var plan volumeResource
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
@@ -220,7 +237,7 @@ func (r *volumeResource) Update(ctx context.Context, req resource.UpdateRequest,
 	updateCtx, cancel := context.WithTimeout(ctx, updateTimeout)
 	defer cancel()
 
-	if err := waitForUnlocked(updateCtx, volumeLocked(), client, plan.Namespace.ValueString(), plan.Name.ValueString()); err != nil {
+	if err := waitForUnlocked(updateCtx, volumeLocked(), client, r.nexaaClient.PollInterval, plan.Namespace.ValueString(), plan.Name.ValueString()); err != nil {
 		resp.Diagnostics.AddError("Error Updating Volume", "Could not reach an unlocked state: "+err.Error())
 		return
 	}
@@ -246,6 +263,9 @@ func (r *volumeResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 // Delete deletes the resource and removes the Terraform state on success.
 func (r *volumeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.StartSpan(ctx, "nexaa_volume.Delete")
+	defer tracing.EndSpan(span, &resp.Diagnostics)
+
 	var state volumeResource
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -269,7 +289,7 @@ func (r *volumeResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	namespaceName := state.Namespace.ValueString()
 	volumeName := state.Name.ValueString()
 
-	err := waitForUnlocked(ctx, volumeLocked(), client, namespaceName, volumeName)
+	err := waitForUnlocked(ctx, volumeLocked(), client, r.nexaaClient.PollInterval, namespaceName, volumeName)
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting volume", "Could not reach a unlocked state: "+err.Error())
 		return
@@ -33,6 +33,11 @@ func (m immutableStringModifier) PlanModifyString(ctx context.Context, req planm
 	if req.StateValue.IsNull() || req.StateValue.IsUnknown() {
 		return
 	}
+	// Computed attributes omitted from the configuration are still unknown here,
+	// their final value is checked once the resource fills it in.
+	if req.PlanValue.IsUnknown() {
+		return
+	}
 	if !req.PlanValue.Equal(req.StateValue) {
 		resp.Diagnostics.AddAttributeError(
 			req.Path,
@@ -38,6 +38,17 @@ func Test_ImmutableString_unknown_state_allows_change(t *testing.T) {
 	assert.False(t, resp.Diagnostics.HasError())
 }
 
+func Test_ImmutableString_unknown_plan_no_error(t *testing.T) {
+	req := planmodifier.StringRequest{
+		Path:       path.Root("test"),
+		StateValue: types.StringValue("old"),
+		PlanValue:  types.StringUnknown(),
+	}
+	var resp planmodifier.StringResponse
+	ImmutableString().PlanModifyString(context.Background(), req, &resp)
+	assert.False(t, resp.Diagnostics.HasError())
+}
+
 func Test_ImmutableString_equal_values_no_error(t *testing.T) {
 	req := planmodifier.StringRequest{
 		Path:       path.Root("test"),
@@ -0,0 +1,137 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/nexaa-cloud/nexaa-cli/api"
+)
+
+// secretEnvHashesPrivateKey stores a keyed hash of every secret environment
+// variable value sent to the API, keyed by name. The API never returns secret
+// values, so the hashes are the only way to tell whether a value in state is
+// the one the container runs with.
+const secretEnvHashesPrivateKey = "secret_environment_variable_hashes"
+
+// secretEnvHashes holds HMAC-SHA256 hashes of secret values under a random key
+// generated for each resource, so equal secrets do not hash the same across
+// resources and a hash cannot be looked up in a precomputed table.
+type secretEnvHashes struct {
+	Key    []byte            `json:"key"`
+	Hashes map[string]string `json:"hashes"`
+}
+
+func newSecretEnvHashes() secretEnvHashes {
+	key := make([]byte, 32)
+	// rand.Read never returns an error.
+	_, _ = rand.Read(key)
+	return secretEnvHashes{Key: key, Hashes: map[string]string{}}
+}
+
+func (h secretEnvHashes) hash(value string) string {
+	mac := hmac.New(sha256.New, h.Key)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// readSecretEnvHashes returns the hashes stored in private state, or an empty
+// set with a new key when none are stored.
+func readSecretEnvHashes(ctx context.Context, private privateStateGetter) (secretEnvHashes, diag.Diagnostics) {
+	hashes := newSecretEnvHashes()
+	data, diags := private.GetKey(ctx, secretEnvHashesPrivateKey)
+	if diags.HasError() || len(data) == 0 {
+		return hashes, diags
+	}
+
+	var stored secretEnvHashes
+	if err := json.Unmarshal(data, &stored); err != nil {
+		diags.AddError("Invalid private state", "Could not read the hashes of the secret environment variables: "+err.Error())
+		return hashes, diags
+	}
+	if len(stored.Key) == 0 {
+		return hashes, diags
+	}
+	if stored.Hashes == nil {
+		stored.Hashes = map[string]string{}
+	}
+	return stored, diags
+}
+
+// recordSecretEnvHashes updates hashes with the environment variables sent to
+// the API. Secrets that are set get the hash of their value, variables that
+// are removed or no longer secret are dropped.
+func recordSecretEnvHashes(hashes secretEnvHashes, inputs []api.EnvironmentVariableInput) secretEnvHashes {
+	for _, in := range inputs {
+		if in.State == api.StatePresent && in.Secret {
+			hashes.Hashes[in.Name] = hashes.hash(in.Value)
+		} else {
+			delete(hashes.Hashes, in.Name)
+		}
+	}
+	return hashes
+}
+
+// storeSecretEnvHashes saves the hashes in private state. Like
+// storeWriteOnlyEnvNames, the key is only cleared when it was set before.
+func storeSecretEnvHashes(ctx context.Context, private privateState, hashes secretEnvHashes) diag.Diagnostics {
+	if len(hashes.Hashes) == 0 {
+		existing, diags := private.GetKey(ctx, secretEnvHashesPrivateKey)
+		if diags.HasError() || len(existing) == 0 {
+			return diags
+		}
+		return private.SetKey(ctx, secretEnvHashesPrivateKey, nil)
+	}
+
+	data, err := json.Marshal(hashes)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Invalid private state", "Could not store the hashes of the secret environment variables: "+err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, secretEnvHashesPrivateKey, data)
+}
+
+// maskStaleSecrets masks secret values in state that do not match the hash of
+// the value last sent to the API, so the next plan sends the configured value
+// again instead of trusting a stale one. Secrets without a recorded hash, such
+// as those in state written by older provider versions, are left unchanged.
+func maskStaleSecrets(ctx context.Context, set types.Set, hashes secretEnvHashes) (types.Set, diag.Diagnostics) {
+	if set.IsNull() || set.IsUnknown() || len(hashes.Hashes) == 0 {
+		return set, nil
+	}
+
+	var envs []environmentVariableResource
+	diags := set.ElementsAs(ctx, &envs, false)
+	if diags.HasError() {
+		return set, diags
+	}
+
+	objType := envVarObjectType()
+	values := make([]attr.Value, 0, len(envs))
+	for _, ev := range envs {
+		value := ev.Value
+		if hash, ok := hashes.Hashes[ev.Name.ValueString()]; ok && ev.Secret.ValueBool() &&
+			!value.IsNull() && !value.IsUnknown() && !hmac.Equal([]byte(hashes.hash(value.ValueString())), []byte(hash)) {
+			value = types.StringValue(maskedSecretValue)
+		}
+		values = append(values, types.ObjectValueMust(objType.AttrTypes, map[string]attr.Value{
+			"name":   ev.Name,
+			"value":  value,
+			"secret": ev.Secret,
+		}))
+	}
+
+	result, d := types.SetValue(objType, values)
+	diags.Append(d...)
+	return result, diags
+}
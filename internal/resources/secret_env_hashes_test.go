@@ -0,0 +1,195 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	providerschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/nexaa-cloud/nexaa-cli/api"
+	nexaaclient "github.com/nexaa-cloud/terraform-provider-nexaa/internal/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RecordSecretEnvHashes(t *testing.T) {
+	hashes := newSecretEnvHashes()
+	hashes.Hashes["REMOVED"] = "x"
+	hashes.Hashes["NOW_PLAIN"] = "y"
+
+	hashes = recordSecretEnvHashes(hashes, []api.EnvironmentVariableInput{
+		{Name: "TOKEN", Value: "s3cret", Secret: true, State: api.StatePresent},
+		{Name: "NOW_PLAIN", Value: "v", State: api.StatePresent},
+		{Name: "REMOVED", State: api.StateAbsent},
+	})
+
+	assert.Equal(t, map[string]string{"TOKEN": hashes.hash("s3cret")}, hashes.Hashes)
+}
+
+func Test_SecretEnvHashes_keyed_per_resource(t *testing.T) {
+	first, second := newSecretEnvHashes(), newSecretEnvHashes()
+	plain := sha256.Sum256([]byte("s3cret"))
+
+	assert.NotEqual(t, first.hash("s3cret"), second.hash("s3cret"))
+	assert.NotEqual(t, hex.EncodeToString(plain[:]), first.hash("s3cret"))
+	assert.Equal(t, first.hash("s3cret"), first.hash("s3cret"))
+}
+
+func Test_SecretEnvHashes_round_trip(t *testing.T) {
+	ctx := context.Background()
+	private := fakePrivateState{}
+	stored := newSecretEnvHashes()
+	stored.Hashes["TOKEN"] = "abc"
+
+	diags := storeSecretEnvHashes(ctx, private, stored)
+	require.False(t, diags.HasError())
+
+	hashes, diags := readSecretEnvHashes(ctx, private)
+	require.False(t, diags.HasError())
+	assert.Equal(t, stored, hashes)
+}
+
+func Test_StoreSecretEnvHashes_empty_leaves_private_state_untouched(t *testing.T) {
+	private := fakePrivateState{}
+	diags := storeSecretEnvHashes(context.Background(), private, newSecretEnvHashes())
+	assert.False(t, diags.HasError())
+	_, set := private[secretEnvHashesPrivateKey]
+	assert.False(t, set)
+}
+
+func Test_MaskStaleSecrets(t *testing.T) {
+	set := envSet(
+		envVar("CURRENT", "kept", true),
+		envVar("STALE", "old", true),
+		envVar("UNTRACKED", "legacy", true),
+		envVar("PLAIN", "value", false),
+	)
+	hashes := newSecretEnvHashes()
+	hashes.Hashes["CURRENT"] = hashes.hash("kept")
+	hashes.Hashes["STALE"] = hashes.hash("rotated")
+
+	result, diags := maskStaleSecrets(context.Background(), set, hashes)
+
+	require.False(t, diags.HasError())
+	assert.True(t, result.Equal(envSet(
+		envVar("CURRENT", "kept", true),
+		envVar("STALE", maskedSecretValue, true),
+		envVar("UNTRACKED", "legacy", true),
+		envVar("PLAIN", "value", false),
+	)))
+}
+
+// secretsTestProvider serves nexaa_container with a mocked API, so Read gets
+// the private state the way Terraform passes it in.
+type secretsTestProvider struct {
+	api nexaaclient.NexaaAPI
+}
+
+func (p *secretsTestProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "nexaa"
+}
+
+func (p *secretsTestProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = providerschema.Schema{}
+}
+
+func (p *secretsTestProvider) Configure(_ context.Context, _ provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	resp.ResourceData = nexaaclient.NewWithAPI(p.api)
+}
+
+func (p *secretsTestProvider) Resources(_ context.Context) []func() resource.Resource {
+	return []func() resource.Resource{func() resource.Resource { return &containerResource{} }}
+}
+
+func (p *secretsTestProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+// readContainerSecrets refreshes a container whose state holds the given
+// environment variables and returns the refreshed environment_variables.
+func readContainerSecrets(t *testing.T, env types.Set, hashes secretEnvHashes) types.Set {
+	t.Helper()
+	ctx := context.Background()
+
+	m := new(nexaaclient.MockNexaaAPI)
+	m.On("ListContainerByName", "test-ns", "my-container").Return(api.ContainerResult{
+		Name:                 "my-container",
+		Image:                "nginx:latest",
+		Resources:            api.ContainerResourcesCpu250Ram500,
+		EnvironmentVariables: []api.EnvironmentVariableResult{{Name: "TOKEN", Secret: true}},
+		NumberOfReplicas:     1,
+		State:                "running",
+	}, nil)
+
+	server, err := providerserver.NewProtocol6WithError(&secretsTestProvider{api: m})()
+	require.NoError(t, err)
+
+	emptyConfig := tftypes.Object{AttributeTypes: map[string]tftypes.Type{}}
+	providerConfig, err := tfprotov6.NewDynamicValue(emptyConfig, tftypes.NewValue(emptyConfig, map[string]tftypes.Value{}))
+	require.NoError(t, err)
+	configured, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: &providerConfig})
+	require.NoError(t, err)
+	require.Empty(t, configured.Diagnostics)
+
+	state := buildContainerState(t, "test-ns", "my-container")
+	require.False(t, state.SetAttribute(ctx, path.Root("environment_variables"), env).HasError())
+	stateType := state.Schema.Type().TerraformType(ctx)
+	current, err := tfprotov6.NewDynamicValue(stateType, state.Raw)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(hashes)
+	require.NoError(t, err)
+	private, err := json.Marshal(map[string][]byte{secretEnvHashesPrivateKey: data})
+	require.NoError(t, err)
+
+	resp, err := server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName:     "nexaa_container",
+		CurrentState: &current,
+		Private:      private,
+	})
+	require.NoError(t, err)
+	require.Empty(t, resp.Diagnostics)
+
+	raw, err := resp.NewState.Unmarshal(stateType)
+	require.NoError(t, err)
+	var refreshed types.Set
+	require.False(t, tfsdk.State{Schema: state.Schema, Raw: raw}.GetAttribute(ctx, path.Root("environment_variables"), &refreshed).HasError())
+	return refreshed
+}
+
+func Test_ContainerRead_stale_secret_drift(t *testing.T) {
+	// The configuration and state hold "old", but the last apply sent
+	// "rotated" to the API. Read masks the stale value so the next plan
+	// reports a diff and sends the configured value again.
+	configured := envSet(envVar("TOKEN", "old", true))
+	hashes := recordSecretEnvHashes(newSecretEnvHashes(), []api.EnvironmentVariableInput{
+		{Name: "TOKEN", Value: "rotated", Secret: true, State: api.StatePresent},
+	})
+
+	refreshed := readContainerSecrets(t, configured, hashes)
+	assert.True(t, refreshed.Equal(envSet(envVar("TOKEN", maskedSecretValue, true))))
+	assert.False(t, refreshed.Equal(configured))
+
+	// Applying that diff sends "old" and records its hash under the same
+	// key, after which Read keeps the value and the diff goes away.
+	hashes = recordSecretEnvHashes(hashes, []api.EnvironmentVariableInput{
+		{Name: "TOKEN", Value: "old", Secret: true, State: api.StatePresent},
+	})
+
+	refreshed = readContainerSecrets(t, configured, hashes)
+	assert.True(t, refreshed.Equal(configured))
+}
@@ -6,8 +6,12 @@ package resources
 import (
 	"context"
 	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
 
 type noEmptyAllowlistValidator struct{}
@@ -30,6 +34,46 @@ func (v noEmptyAllowlistValidator) ValidateList(_ context.Context, req validator
 	}
 }
 
+func (v noEmptyAllowlistValidator) ValidateSet(_ context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+	if !req.ConfigValue.IsNull() && len(req.ConfigValue.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid allowlist",
+			"Allowlist must not be empty. Omit the field to use the defaults (0.0.0.0/0 and ::/0).",
+		)
+	}
+}
+
+// cidrValidator rejects allowlist entries that are neither an IP address nor a CIDR range.
+type cidrValidator struct{}
+
+func (v cidrValidator) Description(_ context.Context) string {
+	return "value must be an IPv4 or IPv6 address or CIDR range"
+}
+
+func (v cidrValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v cidrValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if _, err := netip.ParsePrefix(value); err == nil {
+		return
+	}
+	if _, err := netip.ParseAddr(value); err == nil {
+		return
+	}
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid IP address or CIDR range",
+		fmt.Sprintf("%q is not a valid IPv4 or IPv6 address or CIDR range, for example 192.168.1.0/24 or ::/0.", value),
+	)
+}
+
 type noDuplicateDefaultIngressValidator struct{}
 
 func (v noDuplicateDefaultIngressValidator) Description(_ context.Context) string {
@@ -86,3 +130,264 @@ func (v noDuplicateDefaultIngressValidator) ValidateList(ctx context.Context, re
 		seen[domain] = true
 	}
 }
+
+// containerResourcesValidator rejects cpu/ram combinations the API does not offer.
+type containerResourcesValidator struct{}
+
+func (v containerResourcesValidator) Description(_ context.Context) string {
+	return "cpu and ram must be a combination offered by the API"
+}
+
+func (v containerResourcesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v containerResourcesValidator) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var r containerResourcesResource
+	resp.Diagnostics.Append(req.ConfigValue.As(ctx, &r, basetypes.ObjectAsOptions{})...)
+	if resp.Diagnostics.HasError() || r.CPU.IsNull() || r.CPU.IsUnknown() || r.RAM.IsNull() || r.RAM.IsUnknown() {
+		return
+	}
+
+	cpu, ram := r.CPU.ValueFloat64(), r.RAM.ValueFloat64()
+	if isValidContainerResources(containerResourcesName(cpu, ram)) {
+		return
+	}
+	detail := fmt.Sprintf("CPU %g and RAM %g GB is not a valid combination", cpu, ram)
+	if offered := ramOfferedWithCPU(cpu); len(offered) > 0 {
+		detail += fmt.Sprintf(". With a cpu of %g the API offers a ram of %s GB", cpu, joinFloats(offered))
+	}
+	resp.Diagnostics.AddAttributeError(req.Path, "Invalid container resource combination", detail)
+}
+
+func joinFloats(values []float64) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// uniqueTriggerTypesValidator rejects autoscaling triggers that repeat a type,
+// as the API allows at most one CPU and one MEMORY trigger.
+type uniqueTriggerTypesValidator struct{}
+
+func (v uniqueTriggerTypesValidator) Description(_ context.Context) string {
+	return "At most one trigger per type, so one CPU and one MEMORY trigger."
+}
+
+func (v uniqueTriggerTypesValidator) MarkdownDescription(_ context.Context) string {
+	return "At most one trigger per `type`, so one `CPU` and one `MEMORY` trigger."
+}
+
+func (v uniqueTriggerTypesValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var triggers []triggerResource
+	diags := req.ConfigValue.ElementsAs(ctx, &triggers, false)
+	if diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	seen := make(map[string]bool)
+	for i, trigger := range triggers {
+		if trigger.Type.IsNull() || trigger.Type.IsUnknown() {
+			continue
+		}
+		triggerType := trigger.Type.ValueString()
+		if seen[triggerType] {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtListIndex(i).AtName("type"),
+				"Duplicate autoscaling trigger",
+				fmt.Sprintf("More than one %s trigger is defined. Define at most one CPU and one MEMORY trigger.", triggerType),
+			)
+			return
+		}
+		seen[triggerType] = true
+	}
+}
+
+// portMappingsValidator checks that every port is a from:to mapping of two
+// valid port numbers and that no from port is mapped twice.
+type portMappingsValidator struct{}
+
+func (v portMappingsValidator) Description(_ context.Context) string {
+	return "Each port must be from:to with both ports between 1 and 65535, and every from port may be used once."
+}
+
+func (v portMappingsValidator) MarkdownDescription(_ context.Context) string {
+	return "Each port must be `from:to` with both ports between 1 and 65535, and every `from` port may be used once."
+}
+
+func (v portMappingsValidator) ValidateList(_ context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	seen := make(map[int]bool)
+	for i, elem := range req.ConfigValue.Elements() {
+		port, ok := elem.(basetypes.StringValue)
+		if !ok || port.IsNull() || port.IsUnknown() {
+			continue
+		}
+
+		from, _, err := parsePortMapping(port.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(req.Path.AtListIndex(i), "Invalid port mapping", err.Error())
+			continue
+		}
+		if seen[from] {
+			resp.Diagnostics.AddAttributeError(
+				req.Path.AtListIndex(i),
+				"Duplicate port mapping",
+				fmt.Sprintf("Port %d is mapped more than once.", from),
+			)
+		}
+		seen[from] = true
+	}
+}
+
+// parsePortMapping validates a from:to port mapping and returns both ports.
+func parsePortMapping(mapping string) (int, int, error) {
+	parts := strings.Split(mapping, ":")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("%q must be in the format from:to, for example 8080:80", mapping)
+	}
+
+	ports := make([]int, len(parts))
+	for i, part := range parts {
+		port, err := strconv.Atoi(part)
+		if err != nil || port < 1 || port > 65535 {
+			return 0, 0, fmt.Errorf("%q in %q is not a port between 1 and 65535", part, mapping)
+		}
+		ports[i] = port
+	}
+	return ports[0], ports[1], nil
+}
+
+// cronField is one of the five fields of a cron schedule.
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+	// anyMark reports whether ? may be used for any value.
+	anyMark bool
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31, anyMark: true},
+	{name: "month", min: 1, max: 12, names: map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}},
+	{name: "day of week", min: 0, max: 7, anyMark: true, names: map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}},
+}
+
+var cronMacros = map[string]bool{
+	"@yearly": true, "@annually": true, "@monthly": true, "@weekly": true,
+	"@daily": true, "@midnight": true, "@hourly": true,
+}
+
+// cronScheduleValidator checks container job schedules at plan time, so a
+// typo does not only show up when the API rejects it during apply.
+type cronScheduleValidator struct{}
+
+func (v cronScheduleValidator) Description(_ context.Context) string {
+	return "value must be a cron schedule with the fields minute, hour, day of month, month and day of week"
+}
+
+func (v cronScheduleValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v cronScheduleValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if err := parseCronSchedule(value); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid cron schedule",
+			fmt.Sprintf("%q is not a valid cron schedule: %s. A schedule has five fields: minute, hour, day of month, month and day of week. For example, \"0 4 * * *\" runs every day at 04:00.", value, err),
+		)
+	}
+}
+
+// parseCronSchedule validates a five field cron schedule or one of the
+// @-macros such as @daily.
+func parseCronSchedule(schedule string) error {
+	if cronMacros[strings.TrimSpace(schedule)] {
+		return nil
+	}
+
+	fields := strings.Fields(schedule)
+	if len(fields) != len(cronFields) {
+		return fmt.Errorf("it has %d fields instead of %d", len(fields), len(cronFields))
+	}
+	for i, field := range fields {
+		if err := cronFields[i].validate(field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validate checks a field made of comma separated values, ranges and steps,
+// such as 1,15 or 9-17 or */5.
+func (f cronField) validate(field string) error {
+	for _, part := range strings.Split(field, ",") {
+		values, step, hasStep := strings.Cut(part, "/")
+		if hasStep {
+			if n, err := strconv.Atoi(step); err != nil || n < 1 {
+				return fmt.Errorf("the %s step %q is not a positive number", f.name, step)
+			}
+		}
+		if values == "*" || (values == "?" && f.anyMark) {
+			continue
+		}
+
+		low, high, isRange := strings.Cut(values, "-")
+		from, err := f.value(low)
+		if err != nil {
+			return err
+		}
+		if !isRange {
+			continue
+		}
+		to, err := f.value(high)
+		if err != nil {
+			return err
+		}
+		if to < from {
+			return fmt.Errorf("the %s range %q ends before it starts", f.name, values)
+		}
+	}
+	return nil
+}
+
+func (f cronField) value(s string) (int, error) {
+	if n, ok := f.names[strings.ToUpper(s)]; ok {
+		return n, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("the %s value %q is not a number", f.name, s)
+	}
+	if n < f.min || n > f.max {
+		return 0, fmt.Errorf("the %s value %d is not between %d and %d", f.name, n, f.min, f.max)
+	}
+	return n, nil
+}
@@ -20,7 +20,7 @@ func makeIngressObject(domainName *string, port int64) types.Object {
 	} else {
 		domain = types.StringValue(*domainName)
 	}
-	allowlist := types.ListValueMust(types.StringType, []attr.Value{
+	allowlist := types.SetValueMust(types.StringType, []attr.Value{
 		types.StringValue("0.0.0.0/0"),
 		types.StringValue("::/0"),
 	})
@@ -33,7 +33,7 @@ func makeIngressObject(domainName *string, port int64) types.Object {
 }
 
 func makeIngressObjectUnknownDomain(port int64) types.Object {
-	allowlist := types.ListValueMust(types.StringType, []attr.Value{
+	allowlist := types.SetValueMust(types.StringType, []attr.Value{
 		types.StringValue("0.0.0.0/0"),
 		types.StringValue("::/0"),
 	})
@@ -162,3 +162,135 @@ func Test_NoEmptyAllowlist_empty_list_errors(t *testing.T) {
 	resp := runAllowlistValidator(list)
 	assert.True(t, resp.Diagnostics.HasError())
 }
+
+func Test_NoEmptyAllowlist_empty_set_errors(t *testing.T) {
+	req := validator.SetRequest{ConfigValue: types.SetValueMust(types.StringType, []attr.Value{})}
+	var resp validator.SetResponse
+	noEmptyAllowlistValidator{}.ValidateSet(context.Background(), req, &resp)
+	assert.True(t, resp.Diagnostics.HasError())
+}
+
+// --- cidrValidator ---
+
+func Test_CidrValidator(t *testing.T) {
+	tests := []struct {
+		value   types.String
+		wantErr bool
+	}{
+		{types.StringValue("0.0.0.0/0"), false},
+		{types.StringValue("::/0"), false},
+		{types.StringValue("192.168.1.1/32"), false},
+		{types.StringValue("2001:db8::1"), false},
+		{types.StringValue("10.0.0.0/33"), true},
+		{types.StringValue("example.com"), true},
+		{types.StringNull(), false},
+		{types.StringUnknown(), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value.String(), func(t *testing.T) {
+			var resp validator.StringResponse
+			cidrValidator{}.ValidateString(context.Background(), validator.StringRequest{ConfigValue: tt.value}, &resp)
+			assert.Equal(t, tt.wantErr, resp.Diagnostics.HasError())
+		})
+	}
+}
+
+// --- uniqueTriggerTypesValidator ---
+
+func runTriggerTypesValidator(t *testing.T, triggerTypes ...string) validator.ListResponse {
+	t.Helper()
+	triggerType := types.ObjectType{AttrTypes: map[string]attr.Type{
+		"type":      types.StringType,
+		"threshold": types.Int64Type,
+	}}
+	elems := make([]attr.Value, len(triggerTypes))
+	for i, tt := range triggerTypes {
+		elems[i] = types.ObjectValueMust(triggerType.AttrTypes, map[string]attr.Value{
+			"type":      types.StringValue(tt),
+			"threshold": types.Int64Value(80),
+		})
+	}
+	req := validator.ListRequest{ConfigValue: types.ListValueMust(triggerType, elems)}
+	var resp validator.ListResponse
+	uniqueTriggerTypesValidator{}.ValidateList(context.Background(), req, &resp)
+	return resp
+}
+
+func Test_UniqueTriggerTypes_one_of_each_allowed(t *testing.T) {
+	resp := runTriggerTypesValidator(t, "CPU", "MEMORY")
+	assert.False(t, resp.Diagnostics.HasError())
+}
+
+func Test_UniqueTriggerTypes_duplicate_type_errors(t *testing.T) {
+	resp := runTriggerTypesValidator(t, "CPU", "MEMORY", "CPU")
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "More than one CPU trigger")
+}
+
+// --- portMappingsValidator ---
+
+func runPortMappingsValidator(t *testing.T, ports ...string) validator.ListResponse {
+	t.Helper()
+	elems := make([]attr.Value, len(ports))
+	for i, p := range ports {
+		elems[i] = types.StringValue(p)
+	}
+	req := validator.ListRequest{ConfigValue: types.ListValueMust(types.StringType, elems)}
+	var resp validator.ListResponse
+	portMappingsValidator{}.ValidateList(context.Background(), req, &resp)
+	return resp
+}
+
+func Test_PortMappings_valid(t *testing.T) {
+	resp := runPortMappingsValidator(t, "80:80", "8080:80", "1:65535")
+	assert.False(t, resp.Diagnostics.HasError())
+}
+
+func Test_PortMappings_invalid_format_errors(t *testing.T) {
+	for _, port := range []string{"80", "80:80:80", "http:80", "80:", "0:80", "80:65536", " 80:80"} {
+		resp := runPortMappingsValidator(t, port)
+		assert.True(t, resp.Diagnostics.HasError(), port)
+	}
+}
+
+func Test_PortMappings_duplicate_from_port_errors(t *testing.T) {
+	resp := runPortMappingsValidator(t, "80:80", "443:443", "80:8080")
+	assert.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "Port 80 is mapped more than once")
+}
+
+func Test_PortMappings_unknown_element_skipped(t *testing.T) {
+	req := validator.ListRequest{ConfigValue: types.ListValueMust(types.StringType, []attr.Value{
+		types.StringUnknown(),
+		types.StringValue("80:80"),
+	})}
+	var resp validator.ListResponse
+	portMappingsValidator{}.ValidateList(context.Background(), req, &resp)
+	assert.False(t, resp.Diagnostics.HasError())
+}
+
+func runCronScheduleValidator(schedule string) validator.StringResponse {
+	req := validator.StringRequest{ConfigValue: types.StringValue(schedule)}
+	var resp validator.StringResponse
+	cronScheduleValidator{}.ValidateString(context.Background(), req, &resp)
+	return resp
+}
+
+func Test_CronSchedule_valid(t *testing.T) {
+	for _, schedule := range []string{"0 4 * * *", "*/15 9-17 * * MON-FRI", "0 0 1,15 * ?", "30 2 * JAN,jul 0", "@daily"} {
+		resp := runCronScheduleValidator(schedule)
+		assert.False(t, resp.Diagnostics.HasError(), schedule)
+	}
+}
+
+func Test_CronSchedule_invalid_errors(t *testing.T) {
+	for _, schedule := range []string{"", "0 4 * *", "0 4 * * * *", "60 4 * * *", "0 24 * * *", "0 4 0 * *", "0 4 * 13 *", "0 4 * * 8", "*/0 * * * *", "0 17-9 * * *", "0 4 ? * *,", "@often"} {
+		resp := runCronScheduleValidator(schedule)
+		assert.True(t, resp.Diagnostics.HasError(), schedule)
+	}
+}
+
+func Test_CronSchedule_error_names_the_field(t *testing.T) {
+	resp := runCronScheduleValidator("0 25 * * *")
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "the hour value 25 is not between 0 and 23")
+}
@@ -0,0 +1,53 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	nexaaclient "github.com/nexaa-cloud/terraform-provider-nexaa/internal/client"
+)
+
+// waitForContainerReady polls until the container is unlocked and all its
+// replicas are available, and returns its last seen state. The API reports
+// the state as free text without a fixed set of values, so readiness is taken
+// from the replica counts instead. A container that never gets there, such as
+// one whose image cannot be pulled, fails with its last seen state when ctx
+// expires.
+func waitForContainerReady(ctx context.Context, client nexaaclient.NexaaAPI, pollInterval time.Duration, namespace string, name string) (string, error) {
+	// A poll abandoned on cancellation may still write state, so guard it.
+	var mu sync.Mutex
+	var state string
+	err := poll(ctx, pollInterval, func() (bool, error) {
+		container, err := client.ListContainerByName(namespace, name)
+		if err != nil {
+			return false, err
+		}
+		mu.Lock()
+		state = container.State
+		mu.Unlock()
+
+		if !container.Locked && container.AvailableReplicas > 0 && container.AvailableReplicas >= container.NumberOfReplicas {
+			return true, nil
+		}
+		tflog.Info(ctx, name+" is not ready yet, retrying", map[string]any{
+			"state":              container.State,
+			"available_replicas": container.AvailableReplicas,
+			"replicas":           container.NumberOfReplicas,
+		})
+		return false, nil
+	})
+
+	mu.Lock()
+	defer mu.Unlock()
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return state, fmt.Errorf("container %s did not become ready in time, last state %q: %w", name, state, err)
+	}
+	return state, err
+}
@@ -0,0 +1,61 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nexaa-cloud/nexaa-cli/api"
+	nexaaclient "github.com/nexaa-cloud/terraform-provider-nexaa/internal/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WaitForContainerReady_waits_for_available_replicas(t *testing.T) {
+	m := new(nexaaclient.MockNexaaAPI)
+	m.On("ListContainerByName", "ns", "web").Return(api.ContainerResult{State: "starting", Locked: true, NumberOfReplicas: 2}, nil).Once()
+	m.On("ListContainerByName", "ns", "web").Return(api.ContainerResult{State: "starting", AvailableReplicas: 1, NumberOfReplicas: 2}, nil).Once()
+	m.On("ListContainerByName", "ns", "web").Return(api.ContainerResult{State: "Running", AvailableReplicas: 2, NumberOfReplicas: 2}, nil).Once()
+
+	state, err := waitForContainerReady(context.Background(), m, time.Millisecond, "ns", "web")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Running", state)
+	m.AssertExpectations(t)
+}
+
+func Test_WaitForContainerReady_unknown_state_with_available_replicas(t *testing.T) {
+	m := new(nexaaclient.MockNexaaAPI)
+	m.On("ListContainerByName", "ns", "web").Return(api.ContainerResult{State: "healthy", AvailableReplicas: 1, NumberOfReplicas: 1}, nil).Once()
+
+	state, err := waitForContainerReady(context.Background(), m, time.Millisecond, "ns", "web")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "healthy", state)
+	m.AssertExpectations(t)
+}
+
+func Test_WaitForContainerReady_returns_fetch_error(t *testing.T) {
+	m := new(nexaaclient.MockNexaaAPI)
+	m.On("ListContainerByName", "ns", "web").Return(api.ContainerResult{}, errors.New("boom"))
+
+	_, err := waitForContainerReady(context.Background(), m, time.Millisecond, "ns", "web")
+
+	assert.EqualError(t, err, "boom")
+}
+
+func Test_WaitForContainerReady_reports_last_state_on_timeout(t *testing.T) {
+	m := new(nexaaclient.MockNexaaAPI)
+	m.On("ListContainerByName", "ns", "web").Return(api.ContainerResult{State: "starting"}, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	state, err := waitForContainerReady(ctx, m, 5*time.Millisecond, "ns", "web")
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), `last state "starting"`)
+	assert.Equal(t, "starting", state)
+}
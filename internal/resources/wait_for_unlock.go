@@ -114,44 +114,50 @@ func registryLocked() fetchResourceLocked {
 	}
 }
 
-func waitForUnlocked(ctx context.Context, fetchResourceLocked fetchResourceLocked, client nexaaclient.NexaaAPI, namespace string, resourceName string) error {
-	const (
-		initialDelay = 2 * time.Second
-		maxDelay     = 15 * time.Second
-	)
-	delay := initialDelay
+const (
+	defaultPollInterval = 2 * time.Second
+	maxPollInterval     = 15 * time.Second
+)
+
+// waitForUnlocked polls until the resource is no longer locked. The delay
+// between polls starts at pollInterval, or 2s when it is zero, and doubles
+// after every poll up to 15s or pollInterval when that is larger.
+func waitForUnlocked(ctx context.Context, fetchResourceLocked fetchResourceLocked, client nexaaclient.NexaaAPI, pollInterval time.Duration, namespace string, resourceName string) error {
+	return poll(ctx, pollInterval, func() (bool, error) {
+		locked, err := fetchResourceLocked(client, namespace, resourceName)
+		if err != nil {
+			return false, err
+		}
+		if locked {
+			tflog.Info(ctx, resourceName+" is locked, retrying")
+		}
+		return !locked, nil
+	})
+}
+
+// poll calls check until it reports done or fails, backing off between calls
+// as described on waitForUnlocked.
+func poll(ctx context.Context, pollInterval time.Duration, check func() (bool, error)) error {
+	delay := pollInterval
+	if delay <= 0 {
+		delay = defaultPollInterval
+	}
+	maxDelay := maxPollInterval
+	if delay > maxDelay {
+		maxDelay = delay
+	}
 
 	for {
 		if err := ctx.Err(); err != nil {
 			return err
 		}
 
-		// The Nexaa SDK ignores caller context, so a stuck request would
-		// otherwise pin this loop forever. Run the poll in a goroutine and
-		// race it against ctx.Done so cancellation always wins promptly.
-		// A hung fetch still runs in the background and will be GC'd when
-		// it eventually returns.
-		type pollResult struct {
-			locked bool
-			err    error
+		done, err := callWithContext(ctx, check)
+		if err != nil {
+			return err
 		}
-		ch := make(chan pollResult, 1)
-		go func() {
-			locked, err := fetchResourceLocked(client, namespace, resourceName)
-			ch <- pollResult{locked: locked, err: err}
-		}()
-
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case res := <-ch:
-			if res.err != nil {
-				return res.err
-			}
-			if !res.locked {
-				return nil
-			}
-			tflog.Info(ctx, resourceName+" is locked, retrying")
+		if done {
+			return nil
 		}
 
 		// Cancellable backoff between polls.
@@ -169,3 +175,27 @@ func waitForUnlocked(ctx context.Context, fetchResourceLocked fetchResourceLocke
 		}
 	}
 }
+
+// callWithContext runs call and returns its result, or the context error when
+// ctx ends first. The Nexaa SDK ignores caller context, so a stuck request
+// would otherwise block until the HTTP client gives up. A call that outlives
+// ctx keeps running in the background and is dropped when it returns.
+func callWithContext[T any](ctx context.Context, call func() (T, error)) (T, error) {
+	type result struct {
+		value T
+		err   error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		value, err := call()
+		ch <- result{value: value, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	case res := <-ch:
+		return res.value, res.err
+	}
+}
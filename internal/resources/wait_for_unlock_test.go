@@ -0,0 +1,77 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	nexaaclient "github.com/nexaa-cloud/terraform-provider-nexaa/internal/client"
+	"github.com/stretchr/testify/assert"
+)
+
+// lockedFor returns a fetcher that reports the resource locked for the first
+// n polls.
+func lockedFor(n int, calls *int) fetchResourceLocked {
+	return func(_ nexaaclient.NexaaAPI, _ string, _ string) (bool, error) {
+		*calls++
+		return *calls <= n, nil
+	}
+}
+
+func Test_WaitForUnlocked_uses_poll_interval(t *testing.T) {
+	var calls int
+	start := time.Now()
+
+	err := waitForUnlocked(context.Background(), lockedFor(2, &calls), nil, time.Millisecond, "ns", "res")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func Test_WaitForUnlocked_returns_fetch_error(t *testing.T) {
+	fetch := func(_ nexaaclient.NexaaAPI, _ string, _ string) (bool, error) {
+		return false, errors.New("boom")
+	}
+
+	err := waitForUnlocked(context.Background(), fetch, nil, time.Millisecond, "ns", "res")
+
+	assert.EqualError(t, err, "boom")
+}
+
+func Test_WaitForUnlocked_honours_cancelled_context(t *testing.T) {
+	var calls int
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := waitForUnlocked(ctx, lockedFor(1000, &calls), nil, 5*time.Millisecond, "ns", "res")
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func Test_CallWithContext_returns_result(t *testing.T) {
+	value, err := callWithContext(context.Background(), func() (string, error) {
+		return "web", nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "web", value)
+}
+
+func Test_CallWithContext_gives_up_when_context_ends(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := callWithContext(ctx, func() (string, error) {
+		<-release
+		return "late", nil
+	})
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
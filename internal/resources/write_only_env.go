@@ -0,0 +1,143 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/nexaa-cloud/nexaa-cli/api"
+)
+
+// writeOnlyEnvPrivateKey stores the names of the write-only secret environment
+// variables in private state. Their values never reach state, but the names
+// are needed to keep them out of environment_variables and to remove them
+// when they are dropped from the configuration.
+const writeOnlyEnvPrivateKey = "write_only_environment_variables"
+
+type privateStateGetter interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+type privateState interface {
+	privateStateGetter
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// readWriteOnlyEnv returns the write-only secret environment variables from
+// the configuration, the only place their values are available. An empty
+// configuration, which has no schema to read from, has none.
+func readWriteOnlyEnv(ctx context.Context, config tfsdk.Config) (map[string]string, diag.Diagnostics) {
+	if config.Raw.IsNull() {
+		return nil, nil
+	}
+
+	var values types.Map
+	diags := config.GetAttribute(ctx, path.Root("secret_environment_variables_wo"), &values)
+	if diags.HasError() || values.IsNull() || values.IsUnknown() {
+		return nil, diags
+	}
+
+	result := map[string]string{}
+	diags.Append(values.ElementsAs(ctx, &result, false)...)
+	return result, diags
+}
+
+// writeOnlyEnvInputs converts write-only values into secret environment
+// variable inputs, rejecting names that are also set in environment_variables.
+func writeOnlyEnvInputs(values map[string]string, regular []api.EnvironmentVariableInput) ([]api.EnvironmentVariableInput, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	inputs := make([]api.EnvironmentVariableInput, 0, len(values))
+	for _, name := range names {
+		for _, r := range regular {
+			if r.Name == name {
+				diags.AddAttributeError(
+					path.Root("secret_environment_variables_wo"),
+					"Duplicate environment variable",
+					"Environment variable "+name+" is set in both environment_variables and secret_environment_variables_wo",
+				)
+			}
+		}
+		inputs = append(inputs, api.EnvironmentVariableInput{
+			Name:   name,
+			Value:  values[name],
+			Secret: true,
+			State:  api.StatePresent,
+		})
+	}
+	return inputs, diags
+}
+
+// writeOnlyEnvNames returns the names stored in private state.
+func writeOnlyEnvNames(ctx context.Context, private privateStateGetter) (map[string]bool, diag.Diagnostics) {
+	names := map[string]bool{}
+	data, diags := private.GetKey(ctx, writeOnlyEnvPrivateKey)
+	if diags.HasError() || len(data) == 0 {
+		return names, diags
+	}
+
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		diags.AddError("Invalid private state", "Could not read the names of the write-only environment variables: "+err.Error())
+		return names, diags
+	}
+	for _, n := range list {
+		names[n] = true
+	}
+	return names, diags
+}
+
+// storeWriteOnlyEnvNames saves the names of the write-only environment
+// variables in private state. The key is only cleared when it was set before,
+// so containers without write-only variables keep an empty private state.
+func storeWriteOnlyEnvNames(ctx context.Context, private privateState, names map[string]bool) diag.Diagnostics {
+	if len(names) == 0 {
+		existing, diags := private.GetKey(ctx, writeOnlyEnvPrivateKey)
+		if diags.HasError() || len(existing) == 0 {
+			return diags
+		}
+		return private.SetKey(ctx, writeOnlyEnvPrivateKey, nil)
+	}
+
+	list := make([]string, 0, len(names))
+	for n := range names {
+		list = append(list, n)
+	}
+	sort.Strings(list)
+
+	data, err := json.Marshal(list)
+	if err != nil {
+		var diags diag.Diagnostics
+		diags.AddError("Invalid private state", "Could not store the names of the write-only environment variables: "+err.Error())
+		return diags
+	}
+	return private.SetKey(ctx, writeOnlyEnvPrivateKey, data)
+}
+
+// withoutEnvNames drops the environment variables managed through
+// secret_environment_variables_wo from an API result.
+func withoutEnvNames(vars []api.EnvironmentVariableResult, names map[string]bool) []api.EnvironmentVariableResult {
+	if len(names) == 0 {
+		return vars
+	}
+	filtered := make([]api.EnvironmentVariableResult, 0, len(vars))
+	for _, v := range vars {
+		if !names[v.Name] {
+			filtered = append(filtered, v)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,73 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/nexaa-cloud/nexaa-cli/api"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakePrivateState map[string][]byte
+
+func (f fakePrivateState) GetKey(_ context.Context, key string) ([]byte, diag.Diagnostics) {
+	return f[key], nil
+}
+
+func (f fakePrivateState) SetKey(_ context.Context, key string, value []byte) diag.Diagnostics {
+	f[key] = value
+	return nil
+}
+
+func Test_ReadWriteOnlyEnv_empty_config(t *testing.T) {
+	values, diags := readWriteOnlyEnv(context.Background(), tfsdk.Config{})
+	assert.False(t, diags.HasError())
+	assert.Nil(t, values)
+}
+
+func Test_WriteOnlyEnvInputs_secret_and_sorted(t *testing.T) {
+	inputs, diags := writeOnlyEnvInputs(map[string]string{"B": "2", "A": "1"}, nil)
+	assert.False(t, diags.HasError())
+	assert.Equal(t, []api.EnvironmentVariableInput{
+		{Name: "A", Value: "1", Secret: true, State: api.StatePresent},
+		{Name: "B", Value: "2", Secret: true, State: api.StatePresent},
+	}, inputs)
+}
+
+func Test_WriteOnlyEnvInputs_duplicate_name(t *testing.T) {
+	regular := []api.EnvironmentVariableInput{{Name: "A", Value: "plain", State: api.StatePresent}}
+	_, diags := writeOnlyEnvInputs(map[string]string{"A": "1"}, regular)
+	assert.True(t, diags.HasError())
+}
+
+func Test_WriteOnlyEnvNames_round_trip(t *testing.T) {
+	ctx := context.Background()
+	private := fakePrivateState{}
+
+	diags := storeWriteOnlyEnvNames(ctx, private, map[string]bool{"B": true, "A": true})
+	assert.False(t, diags.HasError())
+	assert.Equal(t, `["A","B"]`, string(private[writeOnlyEnvPrivateKey]))
+
+	names, diags := writeOnlyEnvNames(ctx, private)
+	assert.False(t, diags.HasError())
+	assert.Equal(t, map[string]bool{"A": true, "B": true}, names)
+}
+
+func Test_StoreWriteOnlyEnvNames_empty_leaves_private_state_untouched(t *testing.T) {
+	private := fakePrivateState{}
+	diags := storeWriteOnlyEnvNames(context.Background(), private, nil)
+	assert.False(t, diags.HasError())
+	_, ok := private[writeOnlyEnvPrivateKey]
+	assert.False(t, ok)
+}
+
+func Test_WithoutEnvNames(t *testing.T) {
+	vars := []api.EnvironmentVariableResult{{Name: "A"}, {Name: "B"}}
+	assert.Equal(t, []api.EnvironmentVariableResult{{Name: "B"}}, withoutEnvNames(vars, map[string]bool{"A": true}))
+	assert.Equal(t, vars, withoutEnvNames(vars, nil))
+}
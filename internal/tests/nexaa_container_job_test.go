@@ -16,11 +16,6 @@ func containerJobConfig(namespaceName string, registryName string, registryUsern
 		givenNamespace(namespaceName, "") +
 		givenRegistry(registryName, registryUsername, registryPassword) +
 		fmt.Sprintf(`
-data "nexaa_container_resources" "job" {
-  cpu    = 0.25
-  memory = 0.5
-}
-
 resource "nexaa_container_job" "job" {
   depends_on = [nexaa_registry.registry, nexaa_namespace.ns]
   namespace  = nexaa_namespace.ns.name
@@ -30,7 +25,7 @@ resource "nexaa_container_job" "job" {
   enabled    = false
   command    = %s
   entrypoint = %s
-  resources  = data.nexaa_container_resources.job.id
+  resources  = { cpu = 0.25, ram = 0.5 }
   schedule   = %q
 }
 `, containerJobName, image, command, entrypoint, schedule)
@@ -41,11 +36,6 @@ func containerJobUpdateConfig(namespaceName string, registryName string, registr
 		givenNamespace(namespaceName, "") +
 		givenRegistry(registryName, registryUsername, registryPassword) +
 		fmt.Sprintf(`
-data "nexaa_container_resources" "job" {
-  cpu    = 0.25
-  memory = 0.5
-}
-
 resource "nexaa_container_job" "job" {
   depends_on = [nexaa_registry.registry, nexaa_namespace.ns]
   namespace  = nexaa_namespace.ns.name
@@ -55,7 +45,7 @@ resource "nexaa_container_job" "job" {
   enabled    = false
   command    = %s
   entrypoint = %s
-  resources  = data.nexaa_container_resources.job.id
+  resources  = { cpu = 0.25, ram = 0.5 }
   schedule   = %q
 }
 `, containerJobName, image, registryName, command, entrypoint, schedule)
@@ -199,7 +189,8 @@ func TestAcc_ContainerJobResource_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("nexaa_container_job.job", "image", "nginx:latest"),
 					resource.TestCheckNoResourceAttr("nexaa_container_job.job", "registry"),
 					resource.TestCheckResourceAttr("nexaa_container_job.job", "enabled", "false"),
-					resource.TestCheckResourceAttr("nexaa_container_job.job", "resources", "CPU_250_RAM_500"),
+					resource.TestCheckResourceAttr("nexaa_container_job.job", "resources.cpu", "0.25"),
+					resource.TestCheckResourceAttr("nexaa_container_job.job", "resources.ram", "0.5"),
 					resource.TestCheckResourceAttr("nexaa_container_job.job", "mounts.#", "0"),
 					resource.TestCheckResourceAttr("nexaa_container_job.job", "environment_variables.#", "0"),
 				),
@@ -239,7 +230,8 @@ func TestAcc_ContainerJobResource_basic(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("nexaa_container_job.job", "image", "nginx:alpine"),
 					resource.TestCheckResourceAttr("nexaa_container_job.job", "registry", registryName),
-					resource.TestCheckResourceAttr("nexaa_container_job.job", "resources", "CPU_250_RAM_500"),
+					resource.TestCheckResourceAttr("nexaa_container_job.job", "resources.cpu", "0.25"),
+					resource.TestCheckResourceAttr("nexaa_container_job.job", "resources.ram", "0.5"),
 					resource.TestCheckResourceAttr("nexaa_container_job.job", "mounts.#", "0"),
 					resource.TestCheckResourceAttr("nexaa_container_job.job", "environment_variables.#", "0"),
 				),
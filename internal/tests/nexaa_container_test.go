@@ -19,11 +19,6 @@ func containerConfig(namespaceName, containerName, registryName, registryUsernam
 		givenNamespace(namespaceName, "") +
 		givenRegistry(registryName, registryUsername, registryPassword) +
 		fmt.Sprintf(`
-data "nexaa_container_resources" "small" {
-  cpu    = 0.25
-  memory = 0.5
-}
-
 resource "nexaa_container" "container" {
   depends_on = [nexaa_registry.registry]
   name      = %q
@@ -34,7 +29,7 @@ resource "nexaa_container" "container" {
   command = ["nginx", "-g", "daemon off;"]
   entrypoint = ["/docker-entrypoint.sh"]
 
-  resources = data.nexaa_container_resources.small.id
+  resources = { cpu = 0.25, ram = 0.5 }
 
   ports = ["80:80"]
 
@@ -87,11 +82,6 @@ func containerUpdateConfig(namespaceName, containerName, registryName, registryU
 		givenNamespace(namespaceName, "") +
 		givenRegistry(registryName, registryUsername, registryPassword) +
 		fmt.Sprintf(`
-data "nexaa_container_resources" "medium" {
-  cpu    = 0.5
-  memory = 1.0
-}
-
 resource "nexaa_container" "container" {
   depends_on = [nexaa_registry.registry]
   name      = %q
@@ -102,7 +92,7 @@ resource "nexaa_container" "container" {
   command = ["nginx", "-g", "daemon off;", "-c", "/etc/nginx/nginx.conf"]
   entrypoint = ["/docker-entrypoint.sh"]
 
-  resources = data.nexaa_container_resources.medium.id
+  resources = { cpu = 0.5, ram = 1 }
 
   ports = ["80:80", "%d:%d"]
 
@@ -181,7 +171,8 @@ func TestAcc_ContainerResource_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("nexaa_container.container", "command.2", "daemon off;"),
 					resource.TestCheckResourceAttr("nexaa_container.container", "entrypoint.#", "1"),
 					resource.TestCheckResourceAttr("nexaa_container.container", "entrypoint.0", "/docker-entrypoint.sh"),
-					resource.TestCheckResourceAttr("nexaa_container.container", "resources", "CPU_250_RAM_500"),
+					resource.TestCheckResourceAttr("nexaa_container.container", "resources.cpu", "0.25"),
+					resource.TestCheckResourceAttr("nexaa_container.container", "resources.ram", "0.5"),
 					resource.TestCheckResourceAttr("nexaa_container.container", "ports.#", "1"),
 					resource.TestCheckResourceAttr("nexaa_container.container", "environment_variables.#", "1"),
 					resource.TestCheckResourceAttr("nexaa_container.container", "ingresses.#", "1"),
@@ -236,14 +227,15 @@ func TestAcc_ContainerResource_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("nexaa_container.container", "command.4", "/etc/nginx/nginx.conf"),
 					resource.TestCheckResourceAttr("nexaa_container.container", "entrypoint.#", "1"),
 					resource.TestCheckResourceAttr("nexaa_container.container", "entrypoint.0", "/docker-entrypoint.sh"),
-					resource.TestCheckResourceAttr("nexaa_container.container", "resources", "CPU_500_RAM_1000"),
+					resource.TestCheckResourceAttr("nexaa_container.container", "resources.cpu", "0.5"),
+					resource.TestCheckResourceAttr("nexaa_container.container", "resources.ram", "1"),
 					resource.TestCheckResourceAttr("nexaa_container.container", "ports.#", "2"),
 					resource.TestCheckResourceAttr("nexaa_container.container", "environment_variables.#", "2"),
 					checkEnvironmentVariablesSet(map[string]string{envVar1: envValue1, envVar2: envValue2}),
 					resource.TestCheckResourceAttr("nexaa_container.container", "ingresses.#", "1"),
 					resource.TestCheckResourceAttr("nexaa_container.container", "ingresses.0.port", "80"),
 					resource.TestCheckResourceAttr("nexaa_container.container", "ingresses.0.tls", "true"),
-					resource.TestCheckResourceAttr("nexaa_container.container", "ingresses.0.allowlist.0", "0.0.0.0/0"),
+					resource.TestCheckTypeSetElemAttr("nexaa_container.container", "ingresses.0.allowlist.*", "0.0.0.0/0"),
 					resource.TestCheckResourceAttr("nexaa_container.container", "mounts.#", "0"),
 					resource.TestCheckResourceAttr("nexaa_container.container", "health_check.port", "80"),
 					resource.TestCheckResourceAttr("nexaa_container.container", "health_check.path", healthPath2),
@@ -307,11 +299,6 @@ func checkEnvironmentVariablesSet(expected map[string]string) resource.TestCheck
 
 func minimalContainerConfig(namespaceName, containerName string) string {
 	return givenProvider() + givenNamespace(namespaceName, "") + fmt.Sprintf(`
-data "nexaa_container_resources" "small" {
-  cpu    = 0.25
-  memory = 0.5
-}
-
 resource "nexaa_container" "container" {
   depends_on = [nexaa_namespace.ns]
   name      = %q
@@ -319,7 +306,7 @@ resource "nexaa_container" "container" {
   image     = "nginx:latest"
   registry  = null
 
-  resources = data.nexaa_container_resources.small.id
+  resources = { cpu = 0.25, ram = 0.5 }
 
   scaling = {
     type = "manual"
@@ -387,11 +374,6 @@ func TestAcc_ContainerResource_Minimal(t *testing.T) {
 
 func minimalContainerWithIngressConfig(namespaceName, containerName string) string {
 	return givenProvider() + givenNamespace(namespaceName, "") + fmt.Sprintf(`
-data "nexaa_container_resources" "small" {
-  cpu    = 0.25
-  memory = 0.5
-}
-
 resource "nexaa_container" "container" {
   depends_on = [nexaa_namespace.ns]
   name      = %q
@@ -399,7 +381,7 @@ resource "nexaa_container" "container" {
   image     = "nginx:latest"
   registry  = null
 
-  resources = data.nexaa_container_resources.small.id
+  resources = { cpu = 0.25, ram = 0.5 }
 
   ports = ["80:80"]
 
@@ -489,11 +471,6 @@ func minimalContainerWithDomainNameConfig(namespaceName, containerName string, d
 	return givenProvider() +
 		givenNamespace(namespaceName, "") +
 		fmt.Sprintf(`
-data "nexaa_container_resources" "small" {
-  cpu    = 0.25
-  memory = 0.5
-}
-
 resource "nexaa_container" "container" {
   depends_on = [nexaa_namespace.ns]
   name      = %q
@@ -501,7 +478,7 @@ resource "nexaa_container" "container" {
   image     = "nginx:latest"
   registry  = null
 
-  resources = data.nexaa_container_resources.small.id
+  resources = { cpu = 0.25, ram = 0.5 }
 
   ports = ["80:80"]
 
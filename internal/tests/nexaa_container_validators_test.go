@@ -16,17 +16,12 @@ import (
 
 func containerStartingWithDigit() string {
 	return `
-data "nexaa_container_resources" "small" {
-  cpu    = 0.25
-  memory = 0.5
-}
-
 resource "nexaa_container" "container" {
   depends_on   = [nexaa_namespace.ns]
   namespace    = nexaa_namespace.ns.name
   name         = "1invalid"
   image        = "nginx:latest"
-  resources    = data.nexaa_container_resources.small.id
+  resources    = { cpu = 0.25, ram = 0.5 }
   scaling = {
     type         = "manual"
     manual_input = 1
@@ -104,18 +99,13 @@ func TestAcc_ContainerJobResource_InvalidCronSchedule(t *testing.T) {
 // --- Backend https domain test ---
 
 func containerWithHttpsDomainIngress(containerName string) string {
-	return `
-data "nexaa_container_resources" "small" {
-  cpu    = 0.25
-  memory = 0.5
-}
-` + fmt.Sprintf(`
+	return fmt.Sprintf(`
 resource "nexaa_container" "container" {
   depends_on   = [nexaa_namespace.ns]
   namespace    = nexaa_namespace.ns.name
   name         = %q
   image        = "nginx:latest"
-  resources    = data.nexaa_container_resources.small.id
+  resources    = { cpu = 0.25, ram = 0.5 }
   scaling = {
     type         = "manual"
     manual_input = 1
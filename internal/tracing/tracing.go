@@ -0,0 +1,79 @@
+// Copyright Tilaa B.V. 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package tracing instruments resource operations and API calls with
+// OpenTelemetry spans. Spans are only exported when the standard OTLP
+// environment variables are set, otherwise all spans are no-ops. API call
+// spans are not children of the operation spans, as the API library does not
+// pass a context to its requests.
+package tracing
+
+import (
+	"context"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/nexaa-cloud/terraform-provider-nexaa"
+
+// Enabled reports whether an OTLP endpoint is configured in the environment.
+func Enabled() bool {
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// Setup installs a global tracer provider that exports spans with OTLP over
+// HTTP when Enabled. The returned function flushes the remaining spans and
+// must be called before the process exits.
+func Setup(ctx context.Context, version string) (func(context.Context) error, error) {
+	if !Enabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName("terraform-provider-nexaa"),
+			semconv.ServiceVersion(version),
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// StartSpan starts a span as a child of the span in ctx, if any.
+func StartSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, opts...)
+}
+
+// EndSpan ends a span of a resource or data source operation, marking it as
+// failed when diags contains an error.
+func EndSpan(span trace.Span, diags *diag.Diagnostics) {
+	if diags != nil && diags.HasError() {
+		errs := diags.Errors()
+		span.SetStatus(codes.Error, errs[0].Summary())
+	}
+	span.End()
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 
 	"github.com/nexaa-cloud/terraform-provider-nexaa/internal/provider"
+	"github.com/nexaa-cloud/terraform-provider-nexaa/internal/tracing"
 )
 
 var (
@@ -36,7 +37,19 @@ func main() {
 		Debug:   debug,
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	ctx := context.Background()
+
+	shutdownTracing, err := tracing.Setup(ctx, version)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	err = providerserver.Serve(ctx, provider.New(version), opts)
+
+	// Flush the remaining spans before exiting, also when serving failed.
+	if shutdownErr := shutdownTracing(ctx); shutdownErr != nil {
+		log.Printf("failed to flush traces: %s", shutdownErr)
+	}
 
 	if err != nil {
 		log.Fatal(err.Error())